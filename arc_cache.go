@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+// arcPolicy implements the Adaptive Replacement Cache of Megiddo and Modha.
+// recent holds the entries seen once and frequent the entries seen at least twice, both from LRU to MRU.
+// The ghost lists remember the keys recently evicted from each of them,
+// a hit on a ghost key moves the target size of recent towards the list that would have kept it.
+type arcPolicy[K comparable, V any] struct {
+	size           int
+	target         int
+	recent         *LinkedHashMap[K, V]
+	frequent       *LinkedHashMap[K, V]
+	recentGhosts   *LinkedHashMap[K, struct{}]
+	frequentGhosts *LinkedHashMap[K, struct{}]
+}
+
+// NewARCCache return a Cache that uses the Adaptive Replacement Cache policy,
+// it balances recency and frequency and resists scans of keys that are used once.
+// It remembers up to capacity evicted keys in addition to the cached entries.
+// onEvict is called for each entry evicted to make room and may be nil.
+// It panics if capacity is not positive.
+func NewARCCache[K comparable, V any](capacity int, onEvict func(key K, value V)) Cache[K, V] {
+	checkCacheCapacity(capacity)
+	return newPolicyCache[K, V](&arcPolicy[K, V]{
+		size:           capacity,
+		recent:         NewLinkedHashMap[K, V](),
+		frequent:       NewLinkedHashMap[K, V](),
+		recentGhosts:   NewLinkedHashMap[K, struct{}](),
+		frequentGhosts: NewLinkedHashMap[K, struct{}](),
+	}, onEvict)
+}
+
+func (p *arcPolicy[K, V]) get(key K) (V, bool) {
+	if v, ok := p.recent.Peek(key); ok {
+		p.recent.Delete(key)
+		p.frequent.Store(key, v)
+		return v, true
+	}
+	if v, ok := p.frequent.Peek(key); ok {
+		p.frequent.MoveToEnd(key)
+		return v, true
+	}
+	return *new(V), false
+}
+
+func (p *arcPolicy[K, V]) peek(key K) (V, bool) {
+	if v, ok := p.recent.Peek(key); ok {
+		return v, true
+	}
+	return p.frequent.Peek(key)
+}
+
+func (p *arcPolicy[K, V]) add(key K, value V, evict func(key K, value V)) {
+	if p.recent.Exist(key) {
+		p.recent.Delete(key)
+		p.frequent.Store(key, value)
+		return
+	}
+	if p.frequent.Exist(key) {
+		p.frequent.Store(key, value)
+		p.frequent.MoveToEnd(key)
+		return
+	}
+
+	if p.recentGhosts.Exist(key) {
+		p.target += maxInt(p.frequentGhosts.Size()/p.recentGhosts.Size(), 1)
+		if p.target > p.size {
+			p.target = p.size
+		}
+		p.recentGhosts.Delete(key)
+		p.replace(false, evict)
+		p.frequent.Store(key, value)
+		return
+	}
+	if p.frequentGhosts.Exist(key) {
+		p.target -= maxInt(p.recentGhosts.Size()/p.frequentGhosts.Size(), 1)
+		if p.target < 0 {
+			p.target = 0
+		}
+		p.frequentGhosts.Delete(key)
+		p.replace(true, evict)
+		p.frequent.Store(key, value)
+		return
+	}
+
+	if p.recent.Size()+p.recentGhosts.Size() >= p.size {
+		if p.recent.Size() < p.size {
+			p.dropOldest(p.recentGhosts)
+			p.replace(false, evict)
+		} else {
+			k, v, _ := p.recent.First()
+			p.recent.Delete(k)
+			evict(k, v)
+		}
+	} else if total := p.recent.Size() + p.frequent.Size() + p.recentGhosts.Size() + p.frequentGhosts.Size(); total >= p.size {
+		if total >= 2*p.size {
+			p.dropOldest(p.frequentGhosts)
+		}
+		p.replace(false, evict)
+	}
+	p.recent.Store(key, value)
+}
+
+// replace evicts the LRU entry of recent or frequent into its ghost list if the cache is full.
+// inFrequentGhosts reports whether the key being added was found in the frequent ghost list.
+func (p *arcPolicy[K, V]) replace(inFrequentGhosts bool, evict func(key K, value V)) {
+	if p.recent.Size()+p.frequent.Size() < p.size {
+		return
+	}
+	n := p.recent.Size()
+	if n > 0 && (n > p.target || (n == p.target && inFrequentGhosts) || p.frequent.Size() == 0) {
+		k, v, _ := p.recent.First()
+		p.recent.Delete(k)
+		p.recentGhosts.Store(k, struct{}{})
+		evict(k, v)
+		return
+	}
+	k, v, _ := p.frequent.First()
+	p.frequent.Delete(k)
+	p.frequentGhosts.Store(k, struct{}{})
+	evict(k, v)
+}
+
+func (p *arcPolicy[K, V]) dropOldest(ghosts *LinkedHashMap[K, struct{}]) {
+	if k, _, ok := ghosts.First(); ok {
+		ghosts.Delete(k)
+	}
+}
+
+func (p *arcPolicy[K, V]) remove(key K) (V, bool) {
+	if v, ok := p.recent.Peek(key); ok {
+		p.recent.Delete(key)
+		return v, true
+	}
+	if v, ok := p.frequent.Peek(key); ok {
+		p.frequent.Delete(key)
+		return v, true
+	}
+	return *new(V), false
+}
+
+// rangeEntries visits the entries seen once and then the entries seen at least twice, each from LRU to MRU
+func (p *arcPolicy[K, V]) rangeEntries(f func(key K, value V) bool) {
+	stopped := false
+	p.recent.Range(func(k K, v V) bool {
+		stopped = !f(k, v)
+		return !stopped
+	})
+	if !stopped {
+		p.frequent.Range(f)
+	}
+}
+
+func (p *arcPolicy[K, V]) len() int {
+	return p.recent.Size() + p.frequent.Size()
+}
+
+func (p *arcPolicy[K, V]) capacity() int {
+	return p.size
+}
+
+func (p *arcPolicy[K, V]) clear() {
+	p.target = 0
+	p.recent.DeleteAll()
+	p.frequent.DeleteAll()
+	p.recentGhosts.DeleteAll()
+	p.frequentGhosts.DeleteAll()
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -16,15 +16,22 @@
 
 package gotypes
 
+// SafeArray is an Array that is safe for concurrent use,
+// its batch operations are applied atomically.
 type SafeArray[V any] interface {
-	Add(src ...V)
-	Remove(index int)
-	Set(index int, v V)
-	Get(index int) V
+	Array[V]
+
+	// Insert inserts the values at index, shifting the following elements up.
+	Insert(index int, values ...V)
+	// RemoveRange removes the elements in the half-open interval [from, to).
+	RemoveRange(from, to int)
+	// Swap swaps the elements with indexes i and j.
+	Swap(i, j int)
+	// CompareAndSet sets the element at index to new if it is equal to old, and reports whether it was set.
+	CompareAndSet(index int, old, new V) bool
 	Length() int
 	Capacity() int
-	Range(f func(index int, value V) bool)
-	Each(f func(index int, value V))
+	// Data returns a copy of all elements.
 	Data() []V
 }
 
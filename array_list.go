@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import "sort"
+
+var (
+	_ Array[int]            = (*ArrayList[int])(nil)
+	_ Enumerable2[int, int] = (*ArrayList[int])(nil)
+)
+
+// ArrayList is an Array backed by a Go slice, it has O(1) indexed access and amortised O(1) appends.
+// The zero value is an empty list that compares values with the == operator,
+// or with reflect.DeepEqual if T may hold values that == cannot compare.
+type ArrayList[T any] struct {
+	elements []T
+	equal    func(a, b T) bool
+}
+
+// NewArrayList return an ArrayList that compares values with the == operator
+func NewArrayList[T comparable](values ...T) *ArrayList[T] {
+	return NewArrayListFunc(isEqual[T], values...)
+}
+
+// NewArrayListFunc return an ArrayList that compares values with the equal function
+func NewArrayListFunc[T any](equal func(a, b T) bool, values ...T) *ArrayList[T] {
+	elements := make([]T, len(values))
+	copy(elements, values)
+	return &ArrayList[T]{elements: elements, equal: equal}
+}
+
+func (list *ArrayList[T]) Add(values ...T) {
+	list.elements = append(list.elements, values...)
+}
+
+// Insert inserts the values at index, nothing happens if index is out of [0, Size()].
+func (list *ArrayList[T]) Insert(index int, values ...T) {
+	if index < 0 || index > len(list.elements) {
+		return
+	}
+	list.elements = insertSlice(list.elements, index, values...)
+}
+
+func (list *ArrayList[T]) Remove(index int) {
+	list.RemoveRange(index, index+1)
+}
+
+// RemoveRange removes the elements in [from, to), nothing happens if the interval is invalid.
+func (list *ArrayList[T]) RemoveRange(from, to int) {
+	if from < 0 || to > len(list.elements) || from >= to {
+		return
+	}
+	list.elements = removeSlice(list.elements, from, to)
+}
+
+// RemoveAll removes all elements and keeps the allocated capacity
+func (list *ArrayList[T]) RemoveAll() {
+	list.elements = removeSlice(list.elements, 0, len(list.elements))
+}
+
+func (list *ArrayList[T]) Get(index int) (T, bool) {
+	if !list.checkInRange(index) {
+		return *new(T), false
+	}
+	return list.elements[index], true
+}
+
+// Set replaces the element at index, the value is appended if index equals Size().
+func (list *ArrayList[T]) Set(index int, v T) {
+	if !list.checkInRange(index) {
+		if index == len(list.elements) {
+			list.Add(v)
+		}
+		return
+	}
+	list.elements[index] = v
+}
+
+func (list *ArrayList[T]) Swap(i, j int) {
+	if !list.checkInRange(i) || !list.checkInRange(j) {
+		return
+	}
+	list.elements[i], list.elements[j] = list.elements[j], list.elements[i]
+}
+
+func (list *ArrayList[T]) IndexOf(value T) int {
+	for i, v := range list.elements {
+		if list.comparer()(v, value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Sort sorts the list with the less function, the sort is not guaranteed to be stable.
+func (list *ArrayList[T]) Sort(less func(a, b T) bool) {
+	sort.Slice(list.elements, func(i, j int) bool {
+		return less(list.elements[i], list.elements[j])
+	})
+}
+
+// BinarySearch searches for target in a list sorted in ascending order by cmp,
+// cmp returns a negative number if a < b, zero if a == b and a positive number if a > b.
+// It returns the position where target is found, or the position where it would be inserted,
+// and reports whether it was found.
+func (list *ArrayList[T]) BinarySearch(target T, cmp func(a, b T) int) (int, bool) {
+	i := sort.Search(len(list.elements), func(i int) bool {
+		return cmp(list.elements[i], target) >= 0
+	})
+	return i, i < len(list.elements) && cmp(list.elements[i], target) == 0
+}
+
+// Reverse reverses the order of the elements in place
+func (list *ArrayList[T]) Reverse() {
+	for i, j := 0, len(list.elements)-1; i < j; i, j = i+1, j-1 {
+		list.elements[i], list.elements[j] = list.elements[j], list.elements[i]
+	}
+}
+
+// Clone returns a shallow copy of the list
+func (list *ArrayList[T]) Clone() *ArrayList[T] {
+	return NewArrayListFunc(list.comparer(), list.elements...)
+}
+
+// Grow increases the capacity of the list to guarantee space for another n elements.
+func (list *ArrayList[T]) Grow(n int) {
+	if n <= 0 || cap(list.elements)-len(list.elements) >= n {
+		return
+	}
+	elements := make([]T, len(list.elements), len(list.elements)+n)
+	copy(elements, list.elements)
+	list.elements = elements
+}
+
+// Shrink reduces the capacity of the list to its size.
+func (list *ArrayList[T]) Shrink() {
+	if cap(list.elements) == len(list.elements) {
+		return
+	}
+	elements := make([]T, len(list.elements))
+	copy(elements, list.elements)
+	list.elements = elements
+}
+
+func (list *ArrayList[T]) Capacity() int {
+	return cap(list.elements)
+}
+
+func (list *ArrayList[T]) Range(f func(index int, value T) bool) {
+	for i, v := range list.elements {
+		if !f(i, v) {
+			break
+		}
+	}
+}
+
+func (list *ArrayList[T]) Each(f func(index int, value T)) {
+	for i, v := range list.elements {
+		f(i, v)
+	}
+}
+
+func (list *ArrayList[T]) Every(f func(index int, value T) bool) bool {
+	for i, v := range list.elements {
+		if !f(i, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (list *ArrayList[T]) Some(f func(index int, value T) bool) bool {
+	for i, v := range list.elements {
+		if f(i, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (list *ArrayList[T]) Values() []T {
+	values := make([]T, len(list.elements))
+	copy(values, list.elements)
+	return values
+}
+
+func (list *ArrayList[T]) Empty() bool {
+	return len(list.elements) == 0
+}
+
+func (list *ArrayList[T]) Size() int {
+	return len(list.elements)
+}
+
+// comparer returns the equal function of the list, a zero ArrayList gets the default comparer on first use.
+func (list *ArrayList[T]) comparer() func(a, b T) bool {
+	if list.equal == nil {
+		list.equal = defaultEqual[T]()
+	}
+	return list.equal
+}
+
+func (list *ArrayList[T]) checkInRange(index int) bool {
+	return index >= 0 && index < len(list.elements)
+}
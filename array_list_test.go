@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"reflect"
+	"testing"
+)
+
+var (
+	_ Array[string]            = (*ArrayList[string])(nil)
+	_ Enumerable2[int, string] = (*ArrayList[string])(nil)
+)
+
+func TestArrayListBounds(t *testing.T) {
+	list := NewArrayList(1, 2, 3)
+	list.Insert(0, 0)
+	list.Insert(4, 4)
+	list.Insert(-1, 9)
+	list.Insert(6, 9)
+	if want := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(list.Values(), want) {
+		t.Fatalf("Values() = %v, want %v", list.Values(), want)
+	}
+	list.RemoveRange(-1, 1)
+	list.RemoveRange(4, 6)
+	list.RemoveRange(3, 3)
+	list.RemoveRange(3, 2)
+	if list.Size() != 5 {
+		t.Fatalf("invalid RemoveRange changed the list: %v", list.Values())
+	}
+	list.RemoveRange(0, 1)
+	list.RemoveRange(3, 4)
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(list.Values(), want) {
+		t.Fatalf("Values() = %v, want %v", list.Values(), want)
+	}
+	list.RemoveRange(0, 3)
+	if !list.Empty() {
+		t.Fatalf("list is not empty after removing every element")
+	}
+}
+
+func TestArrayListSortAndSearch(t *testing.T) {
+	list := NewArrayList(5, 1, 4, 2, 8)
+	list.Sort(func(a, b int) bool {
+		return a < b
+	})
+	if want := []int{1, 2, 4, 5, 8}; !reflect.DeepEqual(list.Values(), want) {
+		t.Fatalf("Values() = %v, want %v", list.Values(), want)
+	}
+	for _, c := range []struct {
+		target int
+		index  int
+		found  bool
+	}{
+		{4, 2, true},
+		{0, 0, false},
+		{3, 2, false},
+		{9, 5, false},
+	} {
+		if i, ok := list.BinarySearch(c.target, Compare[int]); i != c.index || ok != c.found {
+			t.Errorf("BinarySearch(%d) = %d, %v, want %d, %v", c.target, i, ok, c.index, c.found)
+		}
+	}
+	list.Reverse()
+	if want := []int{8, 5, 4, 2, 1}; !reflect.DeepEqual(list.Values(), want) {
+		t.Fatalf("Values() after Reverse = %v, want %v", list.Values(), want)
+	}
+}
+
+func TestArrayListCloneAndCapacity(t *testing.T) {
+	list := NewArrayList(1, 2, 3)
+	clone := list.Clone()
+	clone.Set(0, 10)
+	clone.Add(4)
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(list.Values(), want) {
+		t.Fatalf("modifying the clone changed the list: %v", list.Values())
+	}
+	if clone.IndexOf(10) != 0 {
+		t.Errorf("clone lost the comparer")
+	}
+
+	list.Grow(10)
+	if list.Capacity() < 13 {
+		t.Errorf("Capacity() = %d after Grow(10), want at least 13", list.Capacity())
+	}
+	c := list.Capacity()
+	list.Add(4, 5)
+	if list.Capacity() != c {
+		t.Errorf("Add within the grown capacity reallocated")
+	}
+	list.Shrink()
+	if list.Capacity() != list.Size() {
+		t.Errorf("Capacity() = %d after Shrink, want %d", list.Capacity(), list.Size())
+	}
+	list.RemoveAll()
+	if !list.Empty() || list.Capacity() != 5 {
+		t.Errorf("RemoveAll did not keep the capacity")
+	}
+}
+
+func TestArrayListZeroValue(t *testing.T) {
+	var list ArrayList[[]int]
+	list.Add([]int{1}, []int{2})
+	if i := list.IndexOf([]int{2}); i != 1 {
+		t.Errorf("IndexOf([2]) = %d, want 1", i)
+	}
+	if i := list.Clone().IndexOf([]int{1}); i != 0 {
+		t.Errorf("IndexOf([1]) on a clone = %d, want 0", i)
+	}
+}
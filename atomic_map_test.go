@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+var testAtomicMaps = map[string]func() AtomicMap[string, int]{
+	"RWMutexMap": func() AtomicMap[string, int] { return NewRWMutexMap[string, int]() },
+	"SyncMap":    func() AtomicMap[string, int] { return NewSyncMap[string, int]() },
+	"ShardedMap": func() AtomicMap[string, int] { return NewShardedMap[string, int]() },
+}
+
+func TestAtomicMap(t *testing.T) {
+	for name, newMap := range testAtomicMaps {
+		m := newMap()
+		if v, ok := m.Compute("a", func(old int, loaded bool) (int, bool) {
+			return old + 1, !loaded
+		}); !ok || v != 1 {
+			t.Errorf("%s: Compute(a) = %v, %v", name, v, ok)
+		}
+		if v, ok := m.Compute("a", func(int, bool) (int, bool) {
+			return 0, false
+		}); ok || v != 0 || m.Exist("a") || m.Size() != 0 {
+			t.Errorf("%s: Compute(a) did not delete the key", name)
+		}
+		if v, loaded := m.Swap("a", 1); loaded || v != 0 {
+			t.Errorf("%s: Swap(a) = %v, %v", name, v, loaded)
+		}
+		if v, loaded := m.Swap("a", 2); !loaded || v != 1 {
+			t.Errorf("%s: Swap(a) = %v, %v", name, v, loaded)
+		}
+		if m.CompareAndSwap("a", 1, 3) || !m.CompareAndSwap("a", 2, 3) || m.Get("a") != 3 {
+			t.Errorf("%s: CompareAndSwap mismatch", name)
+		}
+		if m.CompareAndSwap("b", 0, 1) || m.Exist("b") {
+			t.Errorf("%s: CompareAndSwap stored an absent key", name)
+		}
+		if m.CompareAndDelete("a", 2) || !m.CompareAndDelete("a", 3) || m.Exist("a") {
+			t.Errorf("%s: CompareAndDelete mismatch", name)
+		}
+		if v, loaded := m.ComputeIfAbsent("c", func() int { return 5 }); loaded || v != 5 {
+			t.Errorf("%s: ComputeIfAbsent(c) = %v, %v", name, v, loaded)
+		}
+		if m.Merge("c", 2, func(old, value int) int { return old * value }) != 10 ||
+			m.Merge("d", 2, func(old, value int) int { return old * value }) != 2 {
+			t.Errorf("%s: Merge mismatch", name)
+		}
+		if m.Size() != 2 {
+			t.Errorf("%s: size = %d, want 2", name, m.Size())
+		}
+	}
+}
+
+func TestAtomicMapConcurrent(t *testing.T) {
+	for name, newMap := range testAtomicMaps {
+		m := newMap()
+		var calls atomic.Int64
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					m.Merge("sum", 1, func(old, value int) int { return old + value })
+					m.ComputeIfAbsent("once", func() int { return int(calls.Add(1)) })
+				}
+			}()
+		}
+		wg.Wait()
+		if v := m.Get("sum"); v != 8000 {
+			t.Errorf("%s: sum = %d, want 8000", name, v)
+		}
+		if calls.Load() != 1 {
+			t.Errorf("%s: factory was called %d times", name, calls.Load())
+		}
+	}
+}
+
+func TestAtomicMapUncomparableValues(t *testing.T) {
+	for name, m := range map[string]AtomicMap[string, []int]{
+		"RWMutexMap": NewRWMutexMap[string, []int](),
+		"SyncMap":    NewSyncMap[string, []int](),
+		"ShardedMap": NewShardedMap[string, []int](),
+	} {
+		m.Store("a", []int{1, 2})
+		if m.CompareAndSwap("a", []int{1}, []int{3}) || !m.CompareAndSwap("a", []int{1, 2}, []int{3}) {
+			t.Errorf("%s: CompareAndSwap mismatch", name)
+		}
+		if m.CompareAndDelete("a", []int{1, 2}) || !m.CompareAndDelete("a", []int{3}) || m.Exist("a") {
+			t.Errorf("%s: CompareAndDelete mismatch", name)
+		}
+	}
+
+	for name, m := range map[string]AtomicMap[string, any]{
+		"RWMutexMap": NewRWMutexMap[string, any](),
+		"SyncMap":    NewSyncMap[string, any](),
+		"ShardedMap": NewShardedMap[string, any](),
+	} {
+		m.Store("a", map[string]int{"x": 1})
+		if !m.CompareAndSwap("a", map[string]int{"x": 1}, 2) || !m.CompareAndDelete("a", 2) {
+			t.Errorf("%s: compare operations on interface values mismatch", name)
+		}
+	}
+
+	sameLength := func(a, b []int) bool {
+		return len(a) == len(b)
+	}
+	for name, m := range map[string]AtomicMap[string, []int]{
+		"RWMutexMap": NewRWMutexMapFunc[string, []int](sameLength),
+		"SyncMap":    NewSyncMapFunc[string, []int](sameLength),
+		"ShardedMap": NewShardedMapFunc[string, []int](4, sameLength),
+	} {
+		m.Store("a", []int{1, 2})
+		if !m.CompareAndSwap("a", []int{5, 6}, []int{3}) || !m.CompareAndDelete("a", []int{9}) {
+			t.Errorf("%s: the equal function was not used", name)
+		}
+	}
+}
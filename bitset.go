@@ -0,0 +1,472 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/lynnplus/gotypes/constraints"
+)
+
+var (
+	_ Container        = (*BitSet)(nil)
+	_ Enumerable[uint] = (*BitSet)(nil)
+	_ Container        = (*BitSetOf[int])(nil)
+	_ Enumerable[int]  = (*BitSetOf[int])(nil)
+)
+
+const wordSize = 64
+
+// BitSet is a dense set of non-negative integers stored one bit per value,
+// it grows as needed when bits are set.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet return a BitSet with room for values below capacity
+func NewBitSet(capacity uint) *BitSet {
+	return &BitSet{words: make([]uint64, wordsFor(capacity))}
+}
+
+func wordsFor(n uint) int {
+	return int((n + wordSize - 1) / wordSize)
+}
+
+// Len returns the number of bits the set can hold without growing
+func (b *BitSet) Len() uint {
+	return uint(len(b.words)) * wordSize
+}
+
+func (b *BitSet) grow(i uint) {
+	n := int(i/wordSize) + 1
+	if n <= len(b.words) {
+		return
+	}
+	if n <= cap(b.words) {
+		b.words = b.words[:n]
+		return
+	}
+	words := make([]uint64, n, n+n/2)
+	copy(words, b.words)
+	b.words = words
+}
+
+func (b *BitSet) Set(i uint) *BitSet {
+	b.grow(i)
+	b.words[i/wordSize] |= 1 << (i % wordSize)
+	return b
+}
+
+func (b *BitSet) Clear(i uint) *BitSet {
+	if w := i / wordSize; w < uint(len(b.words)) {
+		b.words[w] &^= 1 << (i % wordSize)
+	}
+	return b
+}
+
+func (b *BitSet) Flip(i uint) *BitSet {
+	b.grow(i)
+	b.words[i/wordSize] ^= 1 << (i % wordSize)
+	return b
+}
+
+func (b *BitSet) Test(i uint) bool {
+	w := i / wordSize
+	return w < uint(len(b.words)) && b.words[w]&(1<<(i%wordSize)) != 0
+}
+
+// Count returns the number of set bits
+func (b *BitSet) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// NextSet returns the first set bit at or after i, ok is false if there is none.
+func (b *BitSet) NextSet(i uint) (uint, bool) {
+	w := i / wordSize
+	if w >= uint(len(b.words)) {
+		return 0, false
+	}
+	word := b.words[w] >> (i % wordSize)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word)), true
+	}
+	for w++; w < uint(len(b.words)); w++ {
+		if b.words[w] != 0 {
+			return w*wordSize + uint(bits.TrailingZeros64(b.words[w])), true
+		}
+	}
+	return 0, false
+}
+
+// NextClear returns the first clear bit at or after i, bits beyond Len are clear.
+func (b *BitSet) NextClear(i uint) uint {
+	w := i / wordSize
+	if w >= uint(len(b.words)) {
+		return i
+	}
+	word := ^b.words[w] >> (i % wordSize)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word))
+	}
+	for w++; w < uint(len(b.words)); w++ {
+		if b.words[w] != ^uint64(0) {
+			return w*wordSize + uint(bits.TrailingZeros64(^b.words[w]))
+		}
+	}
+	return uint(len(b.words)) * wordSize
+}
+
+// Range calls f for each set bit in ascending order
+func (b *BitSet) Range(f func(i uint) bool) {
+	for w, word := range b.words {
+		for word != 0 {
+			t := bits.TrailingZeros64(word)
+			if !f(uint(w)*wordSize + uint(t)) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
+
+func (b *BitSet) EachValue(f func(value uint)) {
+	b.Range(func(i uint) bool {
+		f(i)
+		return true
+	})
+}
+
+// Values returns all set bits in ascending order
+func (b *BitSet) Values() []uint {
+	r := make([]uint, 0, b.Count())
+	b.EachValue(func(i uint) {
+		r = append(r, i)
+	})
+	return r
+}
+
+func (b *BitSet) Clone() *BitSet {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+	return &BitSet{words: words}
+}
+
+// Equal reports whether both sets contain the same bits, regardless of their lengths
+func (b *BitSet) Equal(other *BitSet) bool {
+	short, long := b.words, other.words
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	for i, w := range short {
+		if w != long[i] {
+			return false
+		}
+	}
+	for _, w := range long[len(short):] {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Empty reports whether no bit is set
+func (b *BitSet) Empty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Size returns the number of set bits, it is the same as Count
+func (b *BitSet) Size() int {
+	return b.Count()
+}
+
+// RemoveAll clears all bits and keeps the allocated words
+func (b *BitSet) RemoveAll() {
+	for i := range b.words {
+		b.words[i] = 0
+	}
+}
+
+// Shrink releases the trailing words that have no set bits
+func (b *BitSet) Shrink() {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	words := make([]uint64, n)
+	copy(words, b.words)
+	b.words = words
+}
+
+// And returns a new set with the bits set in both b and other
+func (b *BitSet) And(other *BitSet) *BitSet {
+	return b.Clone().InPlaceAnd(other)
+}
+
+// Or returns a new set with the bits set in b or other
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	return b.Clone().InPlaceOr(other)
+}
+
+// Xor returns a new set with the bits set in exactly one of b and other
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	return b.Clone().InPlaceXor(other)
+}
+
+// AndNot returns a new set with the bits set in b but not in other
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	return b.Clone().InPlaceAndNot(other)
+}
+
+// InPlaceAnd keeps only the bits that are also set in other, and returns b
+func (b *BitSet) InPlaceAnd(other *BitSet) *BitSet {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+	return b
+}
+
+// InPlaceOr sets the bits that are set in other, and returns b
+func (b *BitSet) InPlaceOr(other *BitSet) *BitSet {
+	b.ensureWords(len(other.words))
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+	return b
+}
+
+// InPlaceXor flips the bits that are set in other, and returns b
+func (b *BitSet) InPlaceXor(other *BitSet) *BitSet {
+	b.ensureWords(len(other.words))
+	for i, w := range other.words {
+		b.words[i] ^= w
+	}
+	return b
+}
+
+// InPlaceAndNot clears the bits that are set in other, and returns b
+func (b *BitSet) InPlaceAndNot(other *BitSet) *BitSet {
+	for i := range b.words {
+		if i >= len(other.words) {
+			break
+		}
+		b.words[i] &^= other.words[i]
+	}
+	return b
+}
+
+func (b *BitSet) ensureWords(n int) {
+	if n > len(b.words) {
+		b.grow(uint(n)*wordSize - 1)
+	}
+}
+
+// MarshalBinary encodes the set as its 64-bit words in little-endian order, trailing zero words are omitted.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	data := make([]byte, n*8)
+	for i, w := range b.words[:n] {
+		binary.LittleEndian.PutUint64(data[i*8:], w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes data produced by MarshalBinary and replaces the content of the set.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return errors.New("gotypes: invalid BitSet encoding length")
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	b.words = words
+	return nil
+}
+
+// BitSetOf is a BitSet whose values are of an integer type, values must not be negative.
+type BitSetOf[T constraints.Integer] struct {
+	bits BitSet
+}
+
+// NewBitSetOf return a BitSetOf that contains the values
+func NewBitSetOf[T constraints.Integer](values ...T) *BitSetOf[T] {
+	s := &BitSetOf[T]{}
+	for _, v := range values {
+		s.Set(v)
+	}
+	return s
+}
+
+func bitIndex[T constraints.Integer](v T) uint {
+	if v < 0 {
+		panic("gotypes: negative value in BitSetOf")
+	}
+	return uint(v)
+}
+
+// Bits returns the underlying BitSet, changes to it are visible in s
+func (s *BitSetOf[T]) Bits() *BitSet {
+	return &s.bits
+}
+
+func (s *BitSetOf[T]) Set(v T) *BitSetOf[T] {
+	s.bits.Set(bitIndex(v))
+	return s
+}
+
+func (s *BitSetOf[T]) Clear(v T) *BitSetOf[T] {
+	if v >= 0 {
+		s.bits.Clear(uint(v))
+	}
+	return s
+}
+
+func (s *BitSetOf[T]) Flip(v T) *BitSetOf[T] {
+	s.bits.Flip(bitIndex(v))
+	return s
+}
+
+func (s *BitSetOf[T]) Test(v T) bool {
+	return v >= 0 && s.bits.Test(uint(v))
+}
+
+func (s *BitSetOf[T]) Count() int {
+	return s.bits.Count()
+}
+
+// NextSet returns the first value in the set at or after v, ok is false if there is none.
+func (s *BitSetOf[T]) NextSet(v T) (T, bool) {
+	if v < 0 {
+		v = 0
+	}
+	i, ok := s.bits.NextSet(uint(v))
+	return T(i), ok
+}
+
+// NextClear returns the first value not in the set at or after v.
+func (s *BitSetOf[T]) NextClear(v T) T {
+	if v < 0 {
+		v = 0
+	}
+	return T(s.bits.NextClear(uint(v)))
+}
+
+// Range calls f for each value in ascending order
+func (s *BitSetOf[T]) Range(f func(v T) bool) {
+	s.bits.Range(func(i uint) bool {
+		return f(T(i))
+	})
+}
+
+func (s *BitSetOf[T]) EachValue(f func(value T)) {
+	s.bits.Range(func(i uint) bool {
+		f(T(i))
+		return true
+	})
+}
+
+// Values returns all values in ascending order
+func (s *BitSetOf[T]) Values() []T {
+	r := make([]T, 0, s.bits.Count())
+	s.EachValue(func(v T) {
+		r = append(r, v)
+	})
+	return r
+}
+
+func (s *BitSetOf[T]) Clone() *BitSetOf[T] {
+	return &BitSetOf[T]{bits: *s.bits.Clone()}
+}
+
+func (s *BitSetOf[T]) Equal(other *BitSetOf[T]) bool {
+	return s.bits.Equal(&other.bits)
+}
+
+func (s *BitSetOf[T]) Empty() bool {
+	return s.bits.Empty()
+}
+
+func (s *BitSetOf[T]) Size() int {
+	return s.bits.Count()
+}
+
+func (s *BitSetOf[T]) RemoveAll() {
+	s.bits.RemoveAll()
+}
+
+func (s *BitSetOf[T]) And(other *BitSetOf[T]) *BitSetOf[T] {
+	return &BitSetOf[T]{bits: *s.bits.And(&other.bits)}
+}
+
+func (s *BitSetOf[T]) Or(other *BitSetOf[T]) *BitSetOf[T] {
+	return &BitSetOf[T]{bits: *s.bits.Or(&other.bits)}
+}
+
+func (s *BitSetOf[T]) Xor(other *BitSetOf[T]) *BitSetOf[T] {
+	return &BitSetOf[T]{bits: *s.bits.Xor(&other.bits)}
+}
+
+func (s *BitSetOf[T]) AndNot(other *BitSetOf[T]) *BitSetOf[T] {
+	return &BitSetOf[T]{bits: *s.bits.AndNot(&other.bits)}
+}
+
+func (s *BitSetOf[T]) InPlaceAnd(other *BitSetOf[T]) *BitSetOf[T] {
+	s.bits.InPlaceAnd(&other.bits)
+	return s
+}
+
+func (s *BitSetOf[T]) InPlaceOr(other *BitSetOf[T]) *BitSetOf[T] {
+	s.bits.InPlaceOr(&other.bits)
+	return s
+}
+
+func (s *BitSetOf[T]) InPlaceXor(other *BitSetOf[T]) *BitSetOf[T] {
+	s.bits.InPlaceXor(&other.bits)
+	return s
+}
+
+func (s *BitSetOf[T]) InPlaceAndNot(other *BitSetOf[T]) *BitSetOf[T] {
+	s.bits.InPlaceAndNot(&other.bits)
+	return s
+}
+
+func (s *BitSetOf[T]) MarshalBinary() ([]byte, error) {
+	return s.bits.MarshalBinary()
+}
+
+func (s *BitSetOf[T]) UnmarshalBinary(data []byte) error {
+	return s.bits.UnmarshalBinary(data)
+}
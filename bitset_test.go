@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBitSet(t *testing.T) {
+	b := NewBitSet(0)
+	b.Set(1).Set(64).Set(130).Flip(2).Flip(1)
+	if want := []uint{2, 64, 130}; !reflect.DeepEqual(b.Values(), want) {
+		t.Fatalf("Values() = %v, want %v", b.Values(), want)
+	}
+	if i, ok := b.NextSet(3); !ok || i != 64 {
+		t.Fatalf("NextSet(3) = %d, %v", i, ok)
+	}
+	if _, ok := b.NextSet(131); ok {
+		t.Fatalf("NextSet(131) found a bit")
+	}
+	if i := b.NextClear(2); i != 3 {
+		t.Fatalf("NextClear(2) = %d", i)
+	}
+
+	other := NewBitSet(0).Set(64).Set(200)
+	if want := []uint{64}; !reflect.DeepEqual(b.And(other).Values(), want) {
+		t.Fatalf("And = %v", b.And(other).Values())
+	}
+	if want := []uint{2, 130, 200}; !reflect.DeepEqual(b.Xor(other).Values(), want) {
+		t.Fatalf("Xor = %v", b.Xor(other).Values())
+	}
+	if want := []uint{2, 130}; !reflect.DeepEqual(b.AndNot(other).Values(), want) {
+		t.Fatalf("AndNot = %v", b.AndNot(other).Values())
+	}
+	if b.Or(other).Count() != 4 {
+		t.Fatalf("Or count = %d", b.Or(other).Count())
+	}
+
+	data, _ := b.MarshalBinary()
+	decoded := &BitSet{}
+	if err := decoded.UnmarshalBinary(data); err != nil || !decoded.Equal(b) {
+		t.Fatalf("binary round trip failed: %v", err)
+	}
+
+	ids := NewBitSetOf[int32](5, 9)
+	if !ids.Test(9) || ids.Test(-1) || ids.Size() != 2 {
+		t.Fatalf("BitSetOf mismatch")
+	}
+}
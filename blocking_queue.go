@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrQueueClosed is returned by the blocking operations of a closed queue
+var ErrQueueClosed = errors.New("gotypes: queue closed")
+
+var (
+	_ Container = (*BlockingQueue[int])(nil)
+)
+
+// BlockingQueue is a concurrency-safe FIFO queue with an optional capacity limit.
+// Put blocks while the queue is full and Take blocks while it is empty,
+// both return early when the context is done or the queue is closed.
+type BlockingQueue[T any] struct {
+	lock     sync.Mutex
+	items    *ArrayDeque[T]
+	capacity int
+	closed   bool
+
+	// notEmpty and notFull are closed and replaced to wake all goroutines waiting on them
+	notEmpty        chan struct{}
+	notFull         chan struct{}
+	notEmptyWaiters int
+	notFullWaiters  int
+}
+
+// NewBlockingQueue return a BlockingQueue that holds at most capacity items,
+// the queue is unbounded if capacity <= 0.
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	return &BlockingQueue[T]{
+		items:    NewArrayDeque[T](),
+		capacity: capacity,
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}
+}
+
+// Put appends v to the queue, waiting for space if the queue is full.
+// It returns ErrQueueClosed if the queue is closed, or the context error if ctx is done first.
+func (q *BlockingQueue[T]) Put(ctx context.Context, v T) error {
+	for {
+		q.lock.Lock()
+		if q.closed {
+			q.lock.Unlock()
+			return ErrQueueClosed
+		}
+		if !q.full() {
+			q.push(v)
+			q.lock.Unlock()
+			return nil
+		}
+		wait := q.notFull
+		q.notFullWaiters++
+		q.lock.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			q.lock.Lock()
+			if q.notFull == wait {
+				q.notFullWaiters--
+			}
+			q.lock.Unlock()
+			return ctx.Err()
+		}
+	}
+}
+
+// Take removes and returns the head of the queue, waiting for an item if the queue is empty.
+// Items left in a closed queue can still be taken, after that it returns ErrQueueClosed.
+// It returns the context error if ctx is done first.
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.lock.Lock()
+		if q.items.Size() > 0 {
+			v := q.pop()
+			q.lock.Unlock()
+			return v, nil
+		}
+		if q.closed {
+			q.lock.Unlock()
+			return *new(T), ErrQueueClosed
+		}
+		wait := q.notEmpty
+		q.notEmptyWaiters++
+		q.lock.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			q.lock.Lock()
+			if q.notEmpty == wait {
+				q.notEmptyWaiters--
+			}
+			q.lock.Unlock()
+			return *new(T), ctx.Err()
+		}
+	}
+}
+
+// Offer appends v to the queue without blocking, and reports whether it was added.
+func (q *BlockingQueue[T]) Offer(v T) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closed || q.full() {
+		return false
+	}
+	q.push(v)
+	return true
+}
+
+// Poll removes and returns the head of the queue without blocking, ok is false if the queue is empty.
+func (q *BlockingQueue[T]) Poll() (v T, ok bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.items.Size() == 0 {
+		return v, false
+	}
+	return q.pop(), true
+}
+
+// Peek returns the head of the queue without removing it.
+func (q *BlockingQueue[T]) Peek() (v T, ok bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.items.PeekFront()
+}
+
+// Drain removes and returns up to n items from the head of the queue without blocking,
+// all items are removed if n <= 0.
+func (q *BlockingQueue[T]) Drain(n int) []T {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if n <= 0 || n > q.items.Size() {
+		n = q.items.Size()
+	}
+	r := make([]T, n)
+	for i := range r {
+		r[i], _ = q.items.PopFront()
+	}
+	if n > 0 {
+		q.signalNotFull()
+	}
+	return r
+}
+
+// Close closes the queue and wakes all waiting goroutines,
+// later calls to Put and Offer fail while the remaining items can still be taken.
+func (q *BlockingQueue[T]) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.signalNotEmpty()
+	q.signalNotFull()
+}
+
+// Closed reports whether the queue is closed
+func (q *BlockingQueue[T]) Closed() bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.closed
+}
+
+// Capacity returns the capacity limit, it is 0 for an unbounded queue
+func (q *BlockingQueue[T]) Capacity() int {
+	if q.capacity <= 0 {
+		return 0
+	}
+	return q.capacity
+}
+
+func (q *BlockingQueue[T]) Empty() bool {
+	return q.Size() == 0
+}
+
+func (q *BlockingQueue[T]) Size() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.items.Size()
+}
+
+func (q *BlockingQueue[T]) RemoveAll() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.items.RemoveAll()
+	q.signalNotFull()
+}
+
+func (q *BlockingQueue[T]) full() bool {
+	return q.capacity > 0 && q.items.Size() >= q.capacity
+}
+
+func (q *BlockingQueue[T]) push(v T) {
+	q.items.PushBack(v)
+	q.signalNotEmpty()
+}
+
+func (q *BlockingQueue[T]) pop() T {
+	v, _ := q.items.PopFront()
+	q.signalNotFull()
+	return v
+}
+
+func (q *BlockingQueue[T]) signalNotEmpty() {
+	if q.notEmptyWaiters > 0 {
+		close(q.notEmpty)
+		q.notEmpty = make(chan struct{})
+		q.notEmptyWaiters = 0
+	}
+}
+
+func (q *BlockingQueue[T]) signalNotFull() {
+	if q.notFullWaiters > 0 {
+		close(q.notFull)
+		q.notFull = make(chan struct{})
+		q.notFullWaiters = 0
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+	if !q.Offer(1) || !q.Offer(2) || q.Offer(3) {
+		t.Fatalf("Offer must fail when the queue is full")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := q.Put(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Put on a full queue = %v, want deadline exceeded", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- q.Put(context.Background(), 3)
+	}()
+	if v, _ := q.Take(context.Background()); v != 1 {
+		t.Fatalf("Take() = %v, want 1", v)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	if r := q.Drain(0); len(r) != 2 || r[0] != 2 || r[1] != 3 {
+		t.Fatalf("Drain(0) = %v", r)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := q.Take(context.Background()); !errors.Is(err, ErrQueueClosed) {
+				t.Errorf("Take() on a closed queue = %v", err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	wg.Wait()
+	if q.Offer(1) || q.Put(context.Background(), 1) != ErrQueueClosed {
+		t.Fatalf("a closed queue must reject new items")
+	}
+}
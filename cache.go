@@ -0,0 +1,237 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import "sync"
+
+var (
+	_ Cache[int, int]  = (*policyCache[int, int])(nil)
+	_ Enumerable[bool] = (*policyCache[int, bool])(nil)
+)
+
+// Cache is a concurrency-safe SafeMap[K,V] with a bounded capacity,
+// storing a new key into a full cache evicts an entry chosen by the cache policy.
+// Get, Load and LoadOrStore count as accesses for the policy and the statistics,
+// other reads such as Peek, Exist and Range do not.
+type Cache[K comparable, V any] interface {
+	SafeMap[K, V]
+
+	// Peek returns the value for the key without counting an access
+	Peek(key K) (value V, ok bool)
+	Capacity() int
+	Stats() CacheStats
+}
+
+// CacheStats is a snapshot of the statistics of a Cache
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRatio returns the ratio of hits to all accesses, it is zero if there was no access.
+func (s CacheStats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type evictedEntry[K any, V any] struct {
+	key   K
+	value V
+}
+
+// cachePolicy decides which entries a policyCache keeps, it is not safe for concurrent use.
+type cachePolicy[K comparable, V any] interface {
+	// get returns the value for the key and records the access
+	get(key K) (V, bool)
+	peek(key K) (V, bool)
+	// add stores the value and records the access, evict is called for each entry it displaces.
+	add(key K, value V, evict func(key K, value V))
+	remove(key K) (V, bool)
+	rangeEntries(f func(key K, value V) bool)
+	len() int
+	capacity() int
+	clear()
+}
+
+// policyCache implements Cache with a cachePolicy guarded by a sync.Mutex,
+// the eviction callback is called after the lock is released.
+type policyCache[K comparable, V any] struct {
+	lock    *sync.Mutex
+	policy  cachePolicy[K, V]
+	stats   CacheStats
+	onEvict func(key K, value V)
+	evicted []evictedEntry[K, V]
+}
+
+func newPolicyCache[K comparable, V any](policy cachePolicy[K, V], onEvict func(key K, value V)) *policyCache[K, V] {
+	return &policyCache[K, V]{
+		lock:    new(sync.Mutex),
+		policy:  policy,
+		onEvict: onEvict,
+	}
+}
+
+func checkCacheCapacity(capacity int) {
+	if capacity <= 0 {
+		panic("gotypes: cache capacity must be positive")
+	}
+}
+
+func (c *policyCache[K, V]) evict(key K, value V) {
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.evicted = append(c.evicted, evictedEntry[K, V]{key, value})
+	}
+}
+
+// unlock releases the lock and then calls the eviction callback for the entries evicted while it was held
+func (c *policyCache[K, V]) unlock() {
+	evicted := c.evicted
+	c.evicted = nil
+	c.lock.Unlock()
+	for _, e := range evicted {
+		c.onEvict(e.key, e.value)
+	}
+}
+
+func (c *policyCache[K, V]) Get(key K) V {
+	val, _ := c.Load(key)
+	return val
+}
+
+func (c *policyCache[K, V]) Exist(key K) (ok bool) {
+	_, ok = c.Peek(key)
+	return ok
+}
+
+func (c *policyCache[K, V]) Store(key K, value V) {
+	c.lock.Lock()
+	defer c.unlock()
+	c.policy.add(key, value, c.evict)
+}
+
+func (c *policyCache[K, V]) Load(key K) (value V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	value, ok = c.policy.get(key)
+	if ok {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+	return value, ok
+}
+
+func (c *policyCache[K, V]) Peek(key K) (value V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.policy.peek(key)
+}
+
+// Range calls f for each entry while holding the lock, f must not modify the cache.
+func (c *policyCache[K, V]) Range(f func(key K, value V) bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.policy.rangeEntries(f)
+}
+
+func (c *policyCache[K, V]) Each(f func(key K, value V)) {
+	c.Range(func(k K, v V) bool {
+		f(k, v)
+		return true
+	})
+}
+
+func (c *policyCache[K, V]) EachValue(f func(value V)) {
+	c.Range(func(_ K, v V) bool {
+		f(v)
+		return true
+	})
+}
+
+func (c *policyCache[K, V]) Keys() []K {
+	var keys []K
+	c.Each(func(k K, _ V) {
+		keys = append(keys, k)
+	})
+	return keys
+}
+
+func (c *policyCache[K, V]) Values() []V {
+	var values []V
+	c.EachValue(func(v V) {
+		values = append(values, v)
+	})
+	return values
+}
+
+func (c *policyCache[K, V]) Size() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.policy.len()
+}
+
+func (c *policyCache[K, V]) Capacity() int {
+	return c.policy.capacity()
+}
+
+func (c *policyCache[K, V]) Stats() CacheStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.stats
+}
+
+func (c *policyCache[K, V]) Delete(key K) {
+	c.LoadAndDelete(key)
+}
+
+// DeleteAll removes all entries without calling the eviction callback, the statistics are kept.
+func (c *policyCache[K, V]) DeleteAll() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.policy.clear()
+}
+
+func (c *policyCache[K, V]) Data() map[K]V {
+	r := make(map[K]V)
+	c.Each(func(k K, v V) {
+		r[k] = v
+	})
+	return r
+}
+
+func (c *policyCache[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	c.lock.Lock()
+	defer c.unlock()
+	if temp, ok := c.policy.get(key); ok {
+		c.stats.Hits++
+		return temp, true
+	}
+	c.stats.Misses++
+	c.policy.add(key, value, c.evict)
+	return value, false
+}
+
+func (c *policyCache[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.policy.remove(key)
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"math/rand"
+	"testing"
+)
+
+var testCaches = map[string]func(capacity int, onEvict func(key, value int)) Cache[int, int]{
+	"LRU": func(capacity int, onEvict func(key, value int)) Cache[int, int] {
+		return NewSafeLRUCache[int, int](capacity, onEvict)
+	},
+	"LFU":     NewLFUCache[int, int],
+	"ARC":     NewARCCache[int, int],
+	"TinyLFU": NewTinyLFUCache[int, int],
+}
+
+func TestCacheModel(t *testing.T) {
+	for _, capacity := range []int{1, 2, 16} {
+		for name, newCache := range testCaches {
+			evicted := uint64(0)
+			c := newCache(capacity, func(int, int) { evicted++ })
+			model := map[int]int{}
+			rnd := rand.New(rand.NewSource(1))
+			for i := 0; i < 20000; i++ {
+				key := rnd.Intn(4 * capacity)
+				switch rnd.Intn(4) {
+				case 0, 1:
+					c.Store(key, i)
+					model[key] = i
+				case 2:
+					if v, ok := c.Load(key); ok && v != model[key] {
+						t.Fatalf("%s/%d: Load(%d) = %d, want %d", name, capacity, key, v, model[key])
+					}
+				case 3:
+					c.Delete(key)
+					delete(model, key)
+				}
+				if c.Size() > capacity {
+					t.Fatalf("%s/%d: size %d exceeds the capacity", name, capacity, c.Size())
+				}
+			}
+			c.Each(func(key, value int) {
+				if model[key] != value {
+					t.Fatalf("%s/%d: entry %d = %d, want %d", name, capacity, key, value, model[key])
+				}
+			})
+			stats := c.Stats()
+			if stats.Hits+stats.Misses == 0 || stats.Evictions == 0 || stats.Evictions != evicted {
+				t.Errorf("%s/%d: stats = %+v", name, capacity, stats)
+			}
+		}
+	}
+}
+
+func TestLFUCache(t *testing.T) {
+	var evicted []int
+	c := NewLFUCache[int, int](2, func(key, _ int) {
+		evicted = append(evicted, key)
+	})
+	c.Store(1, 1)
+	c.Store(2, 2)
+	c.Get(1)
+	c.Store(3, 3)
+	c.Get(3)
+	c.Get(3)
+	c.Store(4, 4)
+	if len(evicted) != 2 || evicted[0] != 2 || evicted[1] != 1 {
+		t.Errorf("evicted = %v, want [2 1]", evicted)
+	}
+	if s := c.Stats(); s.Hits != 3 || s.Misses != 0 || s.Evictions != 2 {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+func TestCacheScanResistance(t *testing.T) {
+	for name, c := range map[string]Cache[int, int]{
+		"ARC":     NewARCCache[int, int](100, nil),
+		"TinyLFU": NewTinyLFUCache[int, int](100, nil),
+	} {
+		for round := 0; round < 5; round++ {
+			for key := 0; key < 50; key++ {
+				if _, ok := c.Load(key); !ok {
+					c.Store(key, key)
+				}
+			}
+		}
+		for key := 1000; key < 3000; key++ {
+			c.Store(key, key)
+		}
+		hot := 0
+		for key := 0; key < 50; key++ {
+			if _, ok := c.Peek(key); ok {
+				hot++
+			}
+		}
+		if hot < 40 {
+			t.Errorf("%s kept %d of 50 hot keys after a scan", name, hot)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	_ SafeArray[int] = (*COWSlice[int])(nil)
+	_ Array[int]     = (*COWSlice[int])(nil)
+)
+
+// COWSlice is a copy-on-write slice that implements the SafeArray[V] interface.
+// Every mutation copies the backing array under a lock and publishes it atomically,
+// so reads never block and see a consistent snapshot. It suits read-heavy workloads with rare writes.
+type COWSlice[V any] struct {
+	lock  *sync.Mutex
+	bm    atomic.Pointer[[]V]
+	equal func(a, b V) bool
+}
+
+// NewCOWSlice return a COWSlice that compares values with the == operator,
+// or with reflect.DeepEqual if V may hold values that == cannot compare.
+func NewCOWSlice[V any](values ...V) *COWSlice[V] {
+	return NewCOWSliceFunc(defaultEqual[V](), values...)
+}
+
+// NewComparableCOWSlice return a COWSlice of comparable values that compares them with the == operator
+func NewComparableCOWSlice[V comparable](values ...V) *COWSlice[V] {
+	return NewCOWSliceFunc(isEqual[V], values...)
+}
+
+// NewCOWSliceFunc return a COWSlice that compares values with the equal function
+func NewCOWSliceFunc[V any](equal func(a, b V) bool, values ...V) *COWSlice[V] {
+	bm := make([]V, len(values))
+	copy(bm, values)
+	s := &COWSlice[V]{
+		lock:  new(sync.Mutex),
+		equal: equal,
+	}
+	s.bm.Store(&bm)
+	return s
+}
+
+// Snapshot returns the current backing array in O(1), the returned slice must not be modified.
+func (c *COWSlice[V]) Snapshot() []V {
+	if p := c.bm.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// mutate publishes the result of f if valid accepts the current array, it reports whether it did.
+// valid is checked before copying, so rejected mutations cost no copy.
+// f receives a copy of the current array that has room for grow more elements.
+func (c *COWSlice[V]) mutate(grow int, valid func(s []V) bool, f func(s []V) []V) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	old := c.Snapshot()
+	if !valid(old) {
+		return false
+	}
+	cp := make([]V, len(old), len(old)+grow)
+	copy(cp, old)
+	r := f(cp)
+	c.bm.Store(&r)
+	return true
+}
+
+func alwaysValid[V any](s []V) bool {
+	return true
+}
+
+func (c *COWSlice[V]) Add(src ...V) {
+	if len(src) == 0 {
+		return
+	}
+	c.mutate(len(src), alwaysValid[V], func(s []V) []V {
+		return append(s, src...)
+	})
+}
+
+// Insert inserts the values at index, nothing happens if index is out of [0, Length()].
+func (c *COWSlice[V]) Insert(index int, values ...V) {
+	c.mutate(len(values), func(s []V) bool {
+		return index >= 0 && index <= len(s)
+	}, func(s []V) []V {
+		return insertSlice(s, index, values...)
+	})
+}
+
+func (c *COWSlice[V]) Remove(index int) {
+	c.RemoveRange(index, index+1)
+}
+
+// RemoveRange removes the elements in [from, to), nothing happens if the interval is invalid.
+func (c *COWSlice[V]) RemoveRange(from, to int) {
+	c.mutate(0, func(s []V) bool {
+		return from >= 0 && to <= len(s) && from < to
+	}, func(s []V) []V {
+		return removeSlice(s, from, to)
+	})
+}
+
+func (c *COWSlice[V]) RemoveAll() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.bm.Store(&[]V{})
+}
+
+// Set replaces the element at index, the value is appended if index equals Length().
+func (c *COWSlice[V]) Set(index int, v V) {
+	c.mutate(1, func(s []V) bool {
+		return index >= 0 && index <= len(s)
+	}, func(s []V) []V {
+		if index == len(s) {
+			return append(s, v)
+		}
+		s[index] = v
+		return s
+	})
+}
+
+func (c *COWSlice[V]) Get(index int) (V, bool) {
+	s := c.Snapshot()
+	if index < 0 || index >= len(s) {
+		return *new(V), false
+	}
+	return s[index], true
+}
+
+func (c *COWSlice[V]) Swap(i, j int) {
+	c.mutate(0, func(s []V) bool {
+		return i >= 0 && j >= 0 && i < len(s) && j < len(s)
+	}, func(s []V) []V {
+		s[i], s[j] = s[j], s[i]
+		return s
+	})
+}
+
+func (c *COWSlice[V]) CompareAndSet(index int, old, new V) bool {
+	return c.mutate(0, func(s []V) bool {
+		return index >= 0 && index < len(s) && c.equal(s[index], old)
+	}, func(s []V) []V {
+		s[index] = new
+		return s
+	})
+}
+
+func (c *COWSlice[V]) IndexOf(value V) int {
+	for i, v := range c.Snapshot() {
+		if c.equal(v, value) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Range calls f for each element of the current snapshot, f may modify the slice.
+func (c *COWSlice[V]) Range(f func(index int, value V) bool) {
+	for i, v := range c.Snapshot() {
+		if !f(i, v) {
+			break
+		}
+	}
+}
+
+func (c *COWSlice[V]) Each(f func(index int, value V)) {
+	c.Range(func(i int, v1 V) bool {
+		f(i, v1)
+		return true
+	})
+}
+
+func (c *COWSlice[V]) Every(f func(index int, value V) bool) bool {
+	ok := true
+	c.Range(func(i int, v1 V) bool {
+		ok = f(i, v1)
+		return ok
+	})
+	return ok
+}
+
+func (c *COWSlice[V]) Some(f func(index int, value V) bool) bool {
+	ok := false
+	c.Range(func(i int, v1 V) bool {
+		ok = f(i, v1)
+		return !ok
+	})
+	return ok
+}
+
+func (c *COWSlice[V]) Values() []V {
+	return c.Data()
+}
+
+func (c *COWSlice[V]) Data() []V {
+	s := c.Snapshot()
+	cp := make([]V, len(s))
+	copy(cp, s)
+	return cp
+}
+
+func (c *COWSlice[V]) Empty() bool {
+	return c.Length() == 0
+}
+
+func (c *COWSlice[V]) Size() int {
+	return c.Length()
+}
+
+func (c *COWSlice[V]) Length() int {
+	return len(c.Snapshot())
+}
+
+func (c *COWSlice[V]) Capacity() int {
+	return cap(c.Snapshot())
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestCOWSliceSnapshot(t *testing.T) {
+	s := NewCOWSlice(1, 2, 3, 4)
+	snapshot := s.Snapshot()
+	s.Add(5)
+	s.Set(0, 10)
+	s.Insert(1, 7)
+	s.RemoveRange(2, 4)
+	s.Swap(0, 1)
+	if want := []int{1, 2, 3, 4}; !reflect.DeepEqual(snapshot, want) {
+		t.Fatalf("snapshot = %v after writes, want %v", snapshot, want)
+	}
+	if want := []int{7, 10, 4, 5}; !reflect.DeepEqual(s.Data(), want) {
+		t.Fatalf("Data() = %v, want %v", s.Data(), want)
+	}
+}
+
+func TestCOWSliceBounds(t *testing.T) {
+	s := NewCOWSlice(1, 2, 3)
+	s.Insert(0, 0)
+	s.Insert(4, 4)
+	before := s.Snapshot()
+	s.Insert(-1, 9)
+	s.Insert(6, 9)
+	s.RemoveRange(-1, 1)
+	s.RemoveRange(2, 6)
+	s.RemoveRange(2, 2)
+	s.Set(-1, 9)
+	s.Set(6, 9)
+	s.Swap(0, 5)
+	if s.CompareAndSet(5, 4, 9) || s.CompareAndSet(-1, 0, 9) {
+		t.Errorf("CompareAndSet out of range set a value")
+	}
+	if after := s.Snapshot(); &after[0] != &before[0] {
+		t.Errorf("rejected writes published a new array")
+	}
+	if want := []int{0, 1, 2, 3, 4}; !reflect.DeepEqual(s.Data(), want) {
+		t.Fatalf("Data() = %v, want %v", s.Data(), want)
+	}
+
+	s.Set(5, 5)
+	s.RemoveRange(0, 1)
+	s.RemoveRange(4, 5)
+	if s.CompareAndSet(0, 2, 9) || !s.CompareAndSet(0, 1, 9) || !s.CompareAndSet(3, 4, 8) {
+		t.Errorf("CompareAndSet mismatch at the bounds")
+	}
+	if want := []int{9, 2, 3, 8}; !reflect.DeepEqual(s.Data(), want) {
+		t.Fatalf("Data() = %v, want %v", s.Data(), want)
+	}
+	s.RemoveRange(0, 4)
+	if !s.Empty() {
+		t.Errorf("slice is not empty after removing every element")
+	}
+}
+
+func TestCOWSliceConcurrent(t *testing.T) {
+	s := NewCOWSlice[int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				s.Add(i)
+				s.Insert(0, i)
+				s.RemoveRange(0, 1)
+				s.Swap(0, s.Length()-1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				sum := 0
+				s.Each(func(_ int, v int) {
+					sum += v
+				})
+				s.IndexOf(i)
+			}
+		}()
+	}
+	wg.Wait()
+	if s.Size() != 800 {
+		t.Fatalf("Size() = %d, want 800", s.Size())
+	}
+}
+
+func TestCOWSliceUncomparableValues(t *testing.T) {
+	s := NewCOWSlice[[]int]([]int{1}, []int{2, 3})
+	if i := s.IndexOf([]int{2, 3}); i != 1 {
+		t.Errorf("IndexOf([2 3]) = %d, want 1", i)
+	}
+	if !s.CompareAndSet(0, []int{1}, []int{4}) || s.CompareAndSet(0, []int{1}, []int{5}) {
+		t.Errorf("CompareAndSet mismatch")
+	}
+	if i := NewComparableCOWSlice("a", "b").IndexOf("b"); i != 1 {
+		t.Errorf("IndexOf(b) = %d, want 1", i)
+	}
+}
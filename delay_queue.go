@@ -0,0 +1,183 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	_ Container = (*DelayQueue[int])(nil)
+)
+
+type delayedItem[T any] struct {
+	value T
+	at    time.Time
+}
+
+// DelayQueue is a concurrency-safe queue of items that become available at a given time,
+// items are taken in the order of their due time.
+type DelayQueue[T any] struct {
+	lock    sync.Mutex
+	clock   Clock
+	items   *PriorityQueue[delayedItem[T]]
+	wakeup  chan struct{}
+	waiters int
+}
+
+// NewDelayQueue return a DelayQueue that uses the SystemClock
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return NewDelayQueueWithClock[T](SystemClock)
+}
+
+// NewDelayQueueWithClock return a DelayQueue that reads the time from the clock
+func NewDelayQueueWithClock[T any](clock Clock) *DelayQueue[T] {
+	return &DelayQueue[T]{
+		clock: clock,
+		items: NewPriorityQueue(func(a, b delayedItem[T]) bool {
+			return a.at.Before(b.at)
+		}),
+		wakeup: make(chan struct{}),
+	}
+}
+
+// Put adds the value that becomes available at the given time
+func (q *DelayQueue[T]) Put(v T, at time.Time) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.items.Push(delayedItem[T]{value: v, at: at})
+	// only a new head changes how long the waiting goroutines have to sleep
+	if head := q.items.PeekItem(); head.Value.at.Equal(at) {
+		q.signal()
+	}
+}
+
+// PutAfter adds the value that becomes available after the delay
+func (q *DelayQueue[T]) PutAfter(v T, delay time.Duration) {
+	q.Put(v, q.clock.Now().Add(delay))
+}
+
+// Poll removes and returns the earliest item if it is due, without blocking.
+func (q *DelayQueue[T]) Poll() (v T, ok bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	head, ok := q.items.Peek()
+	if !ok || head.at.After(q.clock.Now()) {
+		return v, false
+	}
+	q.items.Pop()
+	return head.value, true
+}
+
+// Take removes and returns the earliest item, waiting until it is due.
+// It returns the context error if ctx is done first.
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.lock.Lock()
+		head, ok := q.items.Peek()
+		var delay time.Duration
+		if ok {
+			delay = head.at.Sub(q.clock.Now())
+			if delay <= 0 {
+				q.items.Pop()
+				q.lock.Unlock()
+				return head.value, nil
+			}
+		}
+		wakeup := q.wakeup
+		q.waiters++
+		q.lock.Unlock()
+
+		var timeout <-chan time.Time
+		var timer Timer
+		if ok {
+			timer = q.clock.NewTimer(delay)
+			timeout = timer.C()
+		}
+		select {
+		case <-wakeup:
+		case <-timeout:
+		case <-ctx.Done():
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+
+		q.lock.Lock()
+		if q.wakeup == wakeup {
+			q.waiters--
+		}
+		q.lock.Unlock()
+		if err := ctx.Err(); err != nil {
+			return *new(T), err
+		}
+	}
+}
+
+// DrainExpired removes and returns all items that are due, in the order of their due time.
+func (q *DelayQueue[T]) DrainExpired() []T {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	now := q.clock.Now()
+	var r []T
+	for {
+		head, ok := q.items.Peek()
+		if !ok || head.at.After(now) {
+			return r
+		}
+		q.items.Pop()
+		r = append(r, head.value)
+	}
+}
+
+// NextDelay returns the time until the earliest item is due, ok is false if the queue is empty.
+func (q *DelayQueue[T]) NextDelay() (delay time.Duration, ok bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	head, ok := q.items.Peek()
+	if !ok {
+		return 0, false
+	}
+	return head.at.Sub(q.clock.Now()), true
+}
+
+func (q *DelayQueue[T]) Empty() bool {
+	return q.Size() == 0
+}
+
+func (q *DelayQueue[T]) Size() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.items.Size()
+}
+
+func (q *DelayQueue[T]) RemoveAll() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.items.RemoveAll()
+	q.signal()
+}
+
+func (q *DelayQueue[T]) signal() {
+	if q.waiters > 0 {
+		close(q.wakeup)
+		q.wakeup = make(chan struct{})
+		q.waiters = 0
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// manualClock is a Clock whose time only moves when Advance is called
+type manualClock struct {
+	lock   sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+type manualTimer struct {
+	clock *manualClock
+	at    time.Time
+	c     chan time.Time
+}
+
+func newManualClock() *manualClock {
+	return &manualClock{now: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *manualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *manualClock) NewTimer(d time.Duration) Timer {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	t := &manualTimer{clock: c, at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward and fires the timers that are due
+func (c *manualClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+		} else {
+			t.c <- c.now
+		}
+	}
+	c.timers = pending
+}
+
+// waitTimers waits until n timers are pending
+func (c *manualClock) waitTimers(n int) {
+	for {
+		c.lock.Lock()
+		count := len(c.timers)
+		c.lock.Unlock()
+		if count >= n {
+			return
+		}
+		runtime.Gosched()
+	}
+}
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *manualTimer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+	for i, p := range t.clock.timers {
+		if p == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func TestDelayQueue(t *testing.T) {
+	clock := newManualClock()
+	q := NewDelayQueueWithClock[string](clock)
+	q.PutAfter("b", 2*time.Second)
+	q.PutAfter("a", time.Second)
+	q.PutAfter("c", 3*time.Second)
+
+	if _, ok := q.Poll(); ok {
+		t.Fatalf("Poll() returned an item before it was due")
+	}
+
+	taken := make(chan string)
+	go func() {
+		for i := 0; i < 2; i++ {
+			v, err := q.Take(context.Background())
+			if err != nil {
+				t.Errorf("Take() = %v", err)
+			}
+			taken <- v
+		}
+	}()
+
+	clock.waitTimers(1)
+	clock.Advance(time.Second)
+	if v := <-taken; v != "a" {
+		t.Fatalf("Take() = %q, want a", v)
+	}
+	clock.waitTimers(1)
+	clock.Advance(5 * time.Second)
+	if v := <-taken; v != "b" {
+		t.Fatalf("Take() = %q, want b", v)
+	}
+	if r := q.DrainExpired(); len(r) != 1 || r[0] != "c" {
+		t.Fatalf("DrainExpired() = %v", r)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := q.Take(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Take() on an empty queue = %v, want canceled", err)
+	}
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+var (
+	_ Deque[int]      = (*ArrayDeque[int])(nil)
+	_ Deque[int]      = (*LinkedList[int])(nil)
+	_ Container       = (*Stack[int])(nil)
+	_ Container       = (*Queue[int])(nil)
+	_ Enumerable[int] = (*ArrayDeque[int])(nil)
+)
+
+// Deque is a double-ended queue
+type Deque[T any] interface {
+	Container
+
+	PushFront(v T)
+	PushBack(v T)
+	// PopFront removes and returns the first element, ok is false if the deque is empty.
+	PopFront() (v T, ok bool)
+	// PopBack removes and returns the last element, ok is false if the deque is empty.
+	PopBack() (v T, ok bool)
+	// PeekFront returns the first element without removing it.
+	PeekFront() (v T, ok bool)
+	// PeekBack returns the last element without removing it.
+	PeekBack() (v T, ok bool)
+}
+
+const minDequeCapacity = 8
+
+// ArrayDeque is a Deque backed by a growable ring buffer
+type ArrayDeque[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+// NewArrayDeque return an ArrayDeque
+func NewArrayDeque[T any](values ...T) *ArrayDeque[T] {
+	d := &ArrayDeque[T]{}
+	for _, v := range values {
+		d.PushBack(v)
+	}
+	return d
+}
+
+func (d *ArrayDeque[T]) PushFront(v T) {
+	d.grow()
+	d.head = d.index(-1)
+	d.buf[d.head] = v
+	d.size++
+}
+
+func (d *ArrayDeque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.index(d.size)] = v
+	d.size++
+}
+
+func (d *ArrayDeque[T]) PopFront() (v T, ok bool) {
+	if d.size == 0 {
+		return v, false
+	}
+	v = d.buf[d.head]
+	d.buf[d.head] = *new(T)
+	d.head = d.index(1)
+	d.size--
+	return v, true
+}
+
+func (d *ArrayDeque[T]) PopBack() (v T, ok bool) {
+	if d.size == 0 {
+		return v, false
+	}
+	i := d.index(d.size - 1)
+	v = d.buf[i]
+	d.buf[i] = *new(T)
+	d.size--
+	return v, true
+}
+
+func (d *ArrayDeque[T]) PeekFront() (v T, ok bool) {
+	if d.size == 0 {
+		return v, false
+	}
+	return d.buf[d.head], true
+}
+
+func (d *ArrayDeque[T]) PeekBack() (v T, ok bool) {
+	if d.size == 0 {
+		return v, false
+	}
+	return d.buf[d.index(d.size-1)], true
+}
+
+// Get returns the element at index counted from the front
+func (d *ArrayDeque[T]) Get(index int) (T, bool) {
+	if index < 0 || index >= d.size {
+		return *new(T), false
+	}
+	return d.buf[d.index(index)], true
+}
+
+// Range calls f for each element from front to back
+func (d *ArrayDeque[T]) Range(f func(index int, value T) bool) {
+	for i := 0; i < d.size; i++ {
+		if !f(i, d.buf[d.index(i)]) {
+			break
+		}
+	}
+}
+
+func (d *ArrayDeque[T]) EachValue(f func(value T)) {
+	for i := 0; i < d.size; i++ {
+		f(d.buf[d.index(i)])
+	}
+}
+
+// Values returns all elements from front to back
+func (d *ArrayDeque[T]) Values() []T {
+	values := make([]T, d.size)
+	for i := range values {
+		values[i] = d.buf[d.index(i)]
+	}
+	return values
+}
+
+func (d *ArrayDeque[T]) Empty() bool {
+	return d.size == 0
+}
+
+func (d *ArrayDeque[T]) Size() int {
+	return d.size
+}
+
+func (d *ArrayDeque[T]) RemoveAll() {
+	d.buf = nil
+	d.head = 0
+	d.size = 0
+}
+
+// index maps a position relative to the head into the buffer, the buffer length is a power of two.
+func (d *ArrayDeque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+func (d *ArrayDeque[T]) grow() {
+	if d.size < len(d.buf) {
+		return
+	}
+	n := len(d.buf) * 2
+	if n == 0 {
+		n = minDequeCapacity
+	}
+	buf := make([]T, n)
+	if d.size > 0 {
+		k := copy(buf, d.buf[d.head:])
+		copy(buf[k:], d.buf[:d.head])
+	}
+	d.buf = buf
+	d.head = 0
+}
+
+// Stack is a last-in-first-out collection on top of a Deque
+type Stack[T any] struct {
+	deque Deque[T]
+}
+
+// NewStack return a Stack backed by an ArrayDeque
+func NewStack[T any]() *Stack[T] {
+	return &Stack[T]{deque: NewArrayDeque[T]()}
+}
+
+// NewStackWith return a Stack backed by the given deque, such as a LinkedList
+func NewStackWith[T any](deque Deque[T]) *Stack[T] {
+	return &Stack[T]{deque: deque}
+}
+
+func (s *Stack[T]) Push(v T) {
+	s.deque.PushBack(v)
+}
+
+func (s *Stack[T]) Pop() (T, bool) {
+	return s.deque.PopBack()
+}
+
+func (s *Stack[T]) Peek() (T, bool) {
+	return s.deque.PeekBack()
+}
+
+func (s *Stack[T]) Empty() bool {
+	return s.deque.Empty()
+}
+
+func (s *Stack[T]) Size() int {
+	return s.deque.Size()
+}
+
+func (s *Stack[T]) RemoveAll() {
+	s.deque.RemoveAll()
+}
+
+// Queue is a first-in-first-out collection on top of a Deque
+type Queue[T any] struct {
+	deque Deque[T]
+}
+
+// NewQueue return a Queue backed by an ArrayDeque
+func NewQueue[T any]() *Queue[T] {
+	return &Queue[T]{deque: NewArrayDeque[T]()}
+}
+
+// NewQueueWith return a Queue backed by the given deque, such as a LinkedList
+func NewQueueWith[T any](deque Deque[T]) *Queue[T] {
+	return &Queue[T]{deque: deque}
+}
+
+func (q *Queue[T]) Push(v T) {
+	q.deque.PushBack(v)
+}
+
+func (q *Queue[T]) Pop() (T, bool) {
+	return q.deque.PopFront()
+}
+
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.deque.PeekFront()
+}
+
+func (q *Queue[T]) Empty() bool {
+	return q.deque.Empty()
+}
+
+func (q *Queue[T]) Size() int {
+	return q.deque.Size()
+}
+
+func (q *Queue[T]) RemoveAll() {
+	q.deque.RemoveAll()
+}
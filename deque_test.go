@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArrayDeque(t *testing.T) {
+	d := NewArrayDeque[int]()
+	for i := 0; i < 20; i++ {
+		if i%2 == 0 {
+			d.PushBack(i)
+		} else {
+			d.PushFront(i)
+		}
+	}
+	if v, _ := d.PeekFront(); v != 19 {
+		t.Fatalf("PeekFront() = %v, want 19", v)
+	}
+	if v, _ := d.PeekBack(); v != 18 {
+		t.Fatalf("PeekBack() = %v, want 18", v)
+	}
+	for i := 0; i < 10; i++ {
+		d.PopFront()
+	}
+	if want := []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}; !reflect.DeepEqual(d.Values(), want) {
+		t.Fatalf("Values() = %v, want %v", d.Values(), want)
+	}
+}
+
+func TestStackAndQueue(t *testing.T) {
+	for _, s := range []*Stack[int]{NewStack[int](), NewStackWith[int](NewLinkedList[int]())} {
+		s.Push(1)
+		s.Push(2)
+		if v, ok := s.Pop(); !ok || v != 2 {
+			t.Fatalf("Stack.Pop() = %v, %v, want 2", v, ok)
+		}
+	}
+	for _, q := range []*Queue[int]{NewQueue[int](), NewQueueWith[int](NewLinkedList[int]())} {
+		q.Push(1)
+		q.Push(2)
+		if v, ok := q.Pop(); !ok || v != 1 {
+			t.Fatalf("Queue.Pop() = %v, %v, want 1", v, ok)
+		}
+		q.Pop()
+		if _, ok := q.Pop(); ok || !q.Empty() {
+			t.Fatalf("Queue is not empty")
+		}
+	}
+}
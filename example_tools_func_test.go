@@ -22,15 +22,26 @@ import (
 )
 
 func ExampleConvertTo() {
-	data := map[string]int{}
+	data := NewLinkedHashMap[string, int]()
 
-	data["a"] = 1
-	data["b"] = 2
-	data["c"] = 3
+	data.Store("a", 1)
+	data.Store("b", 2)
+	data.Store("c", 3)
 
-	r := ConvertTo[int](GoMap[string, int](data), func(v int) string {
+	r := ConvertTo[int](data, func(v int) string {
 		return strconv.Itoa(v)
 	})
 	fmt.Println(r)
 	// Output: [1 2 3]
 }
+
+func ExampleLinkedHashMap() {
+	m := NewAccessOrderLinkedHashMap[string, int]()
+	m.Store("a", 1)
+	m.Store("b", 2)
+	m.Store("c", 3)
+
+	m.Get("a")
+	fmt.Println(m.Keys())
+	// Output: [b c a]
+}
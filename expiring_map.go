@@ -0,0 +1,323 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	_ SafeMap[int, int] = (*ExpiringMap[int, int])(nil)
+	_ Enumerable[bool]  = (*ExpiringMap[int, bool])(nil)
+)
+
+type expiringEntry[V any] struct {
+	value V
+	// expireAt is the zero time for entries that never expire
+	expireAt time.Time
+}
+
+func (e *expiringEntry[V]) expired(now time.Time) bool {
+	return !e.expireAt.IsZero() && !now.Before(e.expireAt)
+}
+
+// ExpiringMap is a concurrency-safe map whose entries are removed once their TTL has passed,
+// it implements the SafeMap[K,V] interface.
+// Expired entries are never returned, they are removed lazily when read and periodically by a janitor goroutine.
+// Reads do not extend the TTL of an entry.
+type ExpiringMap[K comparable, V any] struct {
+	lock    *sync.RWMutex
+	bm      map[K]*expiringEntry[V]
+	ttl     time.Duration
+	clock   Clock
+	onEvict []func(key K, value V)
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewExpiringMap return an ExpiringMap whose entries expire after ttl by default,
+// a ttl <= 0 means entries do not expire unless stored with a TTL.
+// If cleanupInterval > 0 a janitor goroutine removes expired entries at that interval until Close is called.
+func NewExpiringMap[K comparable, V any](ttl, cleanupInterval time.Duration) *ExpiringMap[K, V] {
+	return NewExpiringMapWithClock[K, V](ttl, cleanupInterval, SystemClock)
+}
+
+// NewExpiringMapWithClock is like NewExpiringMap but reads the time from the clock
+func NewExpiringMapWithClock[K comparable, V any](ttl, cleanupInterval time.Duration, clock Clock) *ExpiringMap[K, V] {
+	m := &ExpiringMap[K, V]{
+		lock:  new(sync.RWMutex),
+		bm:    make(map[K]*expiringEntry[V]),
+		ttl:   ttl,
+		clock: clock,
+		done:  make(chan struct{}),
+	}
+	if cleanupInterval > 0 {
+		go m.janitor(cleanupInterval)
+	}
+	return m
+}
+
+// OnEvict registers f to be called for each entry removed because it expired,
+// callbacks are called without holding the lock.
+func (m *ExpiringMap[K, V]) OnEvict(f func(key K, value V)) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.onEvict = append(m.onEvict, f)
+}
+
+// Close stops the janitor goroutine, the map stays usable and expires entries lazily afterwards.
+func (m *ExpiringMap[K, V]) Close() {
+	m.once.Do(func() {
+		close(m.done)
+	})
+}
+
+func (m *ExpiringMap[K, V]) Get(key K) V {
+	val, _ := m.Load(key)
+	return val
+}
+
+func (m *ExpiringMap[K, V]) Exist(key K) (ok bool) {
+	_, ok = m.Load(key)
+	return ok
+}
+
+// Store stores the value with the default TTL of the map
+func (m *ExpiringMap[K, V]) Store(key K, value V) {
+	m.StoreWithTTL(key, value, m.ttl)
+}
+
+// StoreWithTTL stores the value that expires after ttl, a ttl <= 0 means it never expires.
+func (m *ExpiringMap[K, V]) StoreWithTTL(key K, value V, ttl time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.bm[key] = m.newEntry(value, ttl)
+}
+
+func (m *ExpiringMap[K, V]) Load(key K) (value V, ok bool) {
+	now := m.clock.Now()
+	m.lock.RLock()
+	e, ok := m.bm[key]
+	if ok && !e.expired(now) {
+		m.lock.RUnlock()
+		return e.value, true
+	}
+	m.lock.RUnlock()
+	if ok {
+		m.expire(key, now)
+	}
+	return value, false
+}
+
+// TTL returns the remaining time to live of the key, it is zero for entries that never expire.
+func (m *ExpiringMap[K, V]) TTL(key K) (ttl time.Duration, ok bool) {
+	now := m.clock.Now()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	e, ok := m.bm[key]
+	if !ok || e.expired(now) {
+		return 0, false
+	}
+	if e.expireAt.IsZero() {
+		return 0, true
+	}
+	return e.expireAt.Sub(now), true
+}
+
+// Range calls f for each unexpired entry while holding the read lock,
+// f must not modify the map.
+func (m *ExpiringMap[K, V]) Range(f func(key K, value V) bool) {
+	now := m.clock.Now()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	for k, e := range m.bm {
+		if e.expired(now) {
+			continue
+		}
+		if !f(k, e.value) {
+			break
+		}
+	}
+}
+
+func (m *ExpiringMap[K, V]) Each(f func(key K, value V)) {
+	m.Range(func(k K, v V) bool {
+		f(k, v)
+		return true
+	})
+}
+
+func (m *ExpiringMap[K, V]) EachValue(f func(value V)) {
+	m.Range(func(_ K, v V) bool {
+		f(v)
+		return true
+	})
+}
+
+func (m *ExpiringMap[K, V]) Keys() []K {
+	var keys []K
+	m.Each(func(k K, _ V) {
+		keys = append(keys, k)
+	})
+	return keys
+}
+
+func (m *ExpiringMap[K, V]) Values() []V {
+	var values []V
+	m.EachValue(func(v V) {
+		values = append(values, v)
+	})
+	return values
+}
+
+// Size returns the number of unexpired entries, it visits every entry.
+func (m *ExpiringMap[K, V]) Size() int {
+	count := 0
+	m.Range(func(K, V) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+func (m *ExpiringMap[K, V]) Delete(key K) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.bm, key)
+}
+
+func (m *ExpiringMap[K, V]) DeleteAll() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.bm = make(map[K]*expiringEntry[V])
+}
+
+// DeleteExpired removes all expired entries and returns the number of removed entries
+func (m *ExpiringMap[K, V]) DeleteExpired() int {
+	now := m.clock.Now()
+	m.lock.Lock()
+	var evicted []K
+	var values []V
+	for k, e := range m.bm {
+		if e.expired(now) {
+			evicted = append(evicted, k)
+			values = append(values, e.value)
+			delete(m.bm, k)
+		}
+	}
+	callbacks := m.onEvict
+	m.lock.Unlock()
+	for i, k := range evicted {
+		for _, f := range callbacks {
+			f(k, values[i])
+		}
+	}
+	return len(evicted)
+}
+
+// Data returns a snapshot of all unexpired entries
+func (m *ExpiringMap[K, V]) Data() map[K]V {
+	r := make(map[K]V)
+	m.Each(func(k K, v V) {
+		r[k] = v
+	})
+	return r
+}
+
+// LoadOrStore is like LoadOrStoreWithTTL with the default TTL of the map
+func (m *ExpiringMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	return m.LoadOrStoreWithTTL(key, value, m.ttl)
+}
+
+// LoadOrStoreWithTTL returns the existing unexpired value for the key if present,
+// otherwise it stores the value that expires after ttl. A ttl <= 0 means it never expires.
+func (m *ExpiringMap[K, V]) LoadOrStoreWithTTL(key K, value V, ttl time.Duration) (actual V, loaded bool) {
+	now := m.clock.Now()
+	m.lock.Lock()
+	e, ok := m.bm[key]
+	if ok && !e.expired(now) {
+		m.lock.Unlock()
+		return e.value, true
+	}
+	m.bm[key] = m.newEntry(value, ttl)
+	callbacks := m.onEvict
+	m.lock.Unlock()
+	if ok {
+		for _, f := range callbacks {
+			f(key, e.value)
+		}
+	}
+	return value, false
+}
+
+func (m *ExpiringMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	now := m.clock.Now()
+	m.lock.Lock()
+	e, ok := m.bm[key]
+	if !ok {
+		m.lock.Unlock()
+		return value, false
+	}
+	delete(m.bm, key)
+	callbacks := m.onEvict
+	m.lock.Unlock()
+	if !e.expired(now) {
+		return e.value, true
+	}
+	for _, f := range callbacks {
+		f(key, e.value)
+	}
+	return value, false
+}
+
+func (m *ExpiringMap[K, V]) newEntry(value V, ttl time.Duration) *expiringEntry[V] {
+	e := &expiringEntry[V]{value: value}
+	if ttl > 0 {
+		e.expireAt = m.clock.Now().Add(ttl)
+	}
+	return e
+}
+
+// expire removes the key if it is still expired at now and calls the eviction callbacks
+func (m *ExpiringMap[K, V]) expire(key K, now time.Time) {
+	m.lock.Lock()
+	e, ok := m.bm[key]
+	if !ok || !e.expired(now) {
+		m.lock.Unlock()
+		return
+	}
+	delete(m.bm, key)
+	callbacks := m.onEvict
+	m.lock.Unlock()
+	for _, f := range callbacks {
+		f(key, e.value)
+	}
+}
+
+func (m *ExpiringMap[K, V]) janitor(interval time.Duration) {
+	for {
+		timer := m.clock.NewTimer(interval)
+		select {
+		case <-timer.C():
+			m.DeleteExpired()
+		case <-m.done:
+			timer.Stop()
+			return
+		}
+	}
+}
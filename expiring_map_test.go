@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestExpiringMap(t *testing.T) {
+	clock := newManualClock()
+	m := NewExpiringMapWithClock[string, int](time.Minute, 0, clock)
+	defer m.Close()
+	var evicted []string
+	m.OnEvict(func(key string, _ int) {
+		evicted = append(evicted, key)
+	})
+
+	m.Store("a", 1)
+	m.StoreWithTTL("b", 2, time.Second)
+	m.StoreWithTTL("c", 3, 0)
+	if ttl, ok := m.TTL("b"); !ok || ttl != time.Second {
+		t.Errorf("TTL(b) = %v, %v", ttl, ok)
+	}
+	if v, loaded := m.LoadOrStoreWithTTL("b", 20, time.Hour); !loaded || v != 2 {
+		t.Errorf("LoadOrStoreWithTTL(b) = %v, %v", v, loaded)
+	}
+
+	clock.Advance(time.Second)
+	if _, ok := m.Load("b"); ok {
+		t.Errorf("Load(b) returned an expired entry")
+	}
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Errorf("evicted = %v, want [b]", evicted)
+	}
+	if v, loaded := m.LoadOrStoreWithTTL("b", 20, time.Hour); loaded || v != 20 {
+		t.Errorf("LoadOrStoreWithTTL(b) after expiry = %v, %v", v, loaded)
+	}
+
+	clock.Advance(time.Minute)
+	keys := m.Keys()
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "b" || keys[1] != "c" || m.Size() != 2 {
+		t.Errorf("Keys() = %v, Size() = %d", keys, m.Size())
+	}
+	if n := m.DeleteExpired(); n != 1 || len(evicted) != 2 || evicted[1] != "a" {
+		t.Errorf("DeleteExpired() = %d, evicted = %v", n, evicted)
+	}
+	if ttl, ok := m.TTL("c"); !ok || ttl != 0 {
+		t.Errorf("TTL(c) = %v, %v", ttl, ok)
+	}
+}
+
+func TestExpiringMapJanitor(t *testing.T) {
+	clock := newManualClock()
+	m := NewExpiringMapWithClock[int, int](time.Second, time.Minute, clock)
+	done := make(chan struct{})
+	m.OnEvict(func(key, _ int) {
+		close(done)
+	})
+	m.Store(1, 1)
+	clock.waitTimers(1)
+	clock.Advance(time.Minute)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("janitor did not evict the expired entry")
+	}
+	m.Close()
+	m.Close()
+}
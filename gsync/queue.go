@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gsync
+
+import (
+	"sync/atomic"
+)
+
+// cacheLinePad keeps hot atomic fields on separate cache lines to avoid false sharing
+type cacheLinePad struct {
+	_ [64]byte
+}
+
+type mpmcCell[T any] struct {
+	sequence atomic.Uint64
+	value    T
+}
+
+// MPMCQueue is a bounded lock-free multi-producer multi-consumer queue.
+// It is a ring buffer whose cells carry sequence numbers, as described by Dmitry Vyukov.
+type MPMCQueue[T any] struct {
+	_       cacheLinePad
+	enqueue atomic.Uint64
+	_       cacheLinePad
+	dequeue atomic.Uint64
+	_       cacheLinePad
+	mask    uint64
+	cells   []mpmcCell[T]
+}
+
+// NewMPMCQueue return an MPMCQueue, the capacity is rounded up to a power of two and is at least 2.
+func NewMPMCQueue[T any](capacity int) *MPMCQueue[T] {
+	size := uint64(2)
+	for size < uint64(capacity) {
+		size <<= 1
+	}
+	q := &MPMCQueue[T]{
+		mask:  size - 1,
+		cells: make([]mpmcCell[T], size),
+	}
+	for i := range q.cells {
+		q.cells[i].sequence.Store(uint64(i))
+	}
+	return q
+}
+
+// Offer appends v to the queue and reports whether it was added, it fails if the queue is full.
+func (q *MPMCQueue[T]) Offer(v T) bool {
+	pos := q.enqueue.Load()
+	var cell *mpmcCell[T]
+	for {
+		cell = &q.cells[pos&q.mask]
+		dif := int64(cell.sequence.Load() - pos)
+		if dif == 0 {
+			if q.enqueue.CompareAndSwap(pos, pos+1) {
+				break
+			}
+		} else if dif < 0 {
+			return false
+		}
+		pos = q.enqueue.Load()
+	}
+	cell.value = v
+	cell.sequence.Store(pos + 1)
+	return true
+}
+
+// Poll removes and returns the head of the queue, ok is false if the queue is empty.
+func (q *MPMCQueue[T]) Poll() (v T, ok bool) {
+	pos := q.dequeue.Load()
+	var cell *mpmcCell[T]
+	for {
+		cell = &q.cells[pos&q.mask]
+		dif := int64(cell.sequence.Load() - (pos + 1))
+		if dif == 0 {
+			if q.dequeue.CompareAndSwap(pos, pos+1) {
+				break
+			}
+		} else if dif < 0 {
+			return v, false
+		}
+		pos = q.dequeue.Load()
+	}
+	v = cell.value
+	cell.value = *new(T)
+	cell.sequence.Store(pos + q.mask + 1)
+	return v, true
+}
+
+// Size returns the number of items in the queue, it is only a snapshot under concurrent use.
+func (q *MPMCQueue[T]) Size() int {
+	dequeue := q.dequeue.Load()
+	enqueue := q.enqueue.Load()
+	if enqueue < dequeue {
+		return 0
+	}
+	return int(enqueue - dequeue)
+}
+
+func (q *MPMCQueue[T]) Capacity() int {
+	return len(q.cells)
+}
+
+type msNode[T any] struct {
+	value T
+	next  atomic.Pointer[msNode[T]]
+}
+
+// UnboundedMPMCQueue is an unbounded lock-free multi-producer multi-consumer queue,
+// it is the linked queue described by Michael and Scott.
+type UnboundedMPMCQueue[T any] struct {
+	_    cacheLinePad
+	head atomic.Pointer[msNode[T]]
+	_    cacheLinePad
+	tail atomic.Pointer[msNode[T]]
+	_    cacheLinePad
+	size atomic.Int64
+}
+
+// NewUnboundedMPMCQueue return an empty UnboundedMPMCQueue
+func NewUnboundedMPMCQueue[T any]() *UnboundedMPMCQueue[T] {
+	q := &UnboundedMPMCQueue[T]{}
+	dummy := &msNode[T]{}
+	q.head.Store(dummy)
+	q.tail.Store(dummy)
+	return q
+}
+
+// Push appends v to the queue
+func (q *UnboundedMPMCQueue[T]) Push(v T) {
+	n := &msNode[T]{value: v}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// the tail is lagging behind, help to advance it
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, n) {
+			q.tail.CompareAndSwap(tail, n)
+			q.size.Add(1)
+			return
+		}
+	}
+}
+
+// Pop removes and returns the head of the queue, ok is false if the queue is empty.
+func (q *UnboundedMPMCQueue[T]) Pop() (v T, ok bool) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+		if next == nil {
+			return v, false
+		}
+		if head == tail {
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		v = next.value
+		if q.head.CompareAndSwap(head, next) {
+			q.size.Add(-1)
+			return v, true
+		}
+	}
+}
+
+// Size returns the number of items in the queue, it is only a snapshot under concurrent use.
+func (q *UnboundedMPMCQueue[T]) Size() int {
+	if n := q.size.Load(); n > 0 {
+		return int(n)
+	}
+	return 0
+}
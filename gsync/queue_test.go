@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gsync
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/lynnplus/gotypes"
+)
+
+func TestMPMCQueue(t *testing.T) {
+	const producers, items = 4, 2000
+	q := NewMPMCQueue[int](64)
+	u := NewUnboundedMPMCQueue[int]()
+
+	var wg sync.WaitGroup
+	var sum, usum sync.Map
+	for p := 0; p < producers; p++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 1; i <= items; i++ {
+				for !q.Offer(i) {
+					runtime.Gosched()
+				}
+				u.Push(i)
+			}
+		}()
+		go func(p int) {
+			defer wg.Done()
+			total, utotal := 0, 0
+			for n := 0; n < items; {
+				if v, ok := q.Poll(); ok {
+					total += v
+					n++
+				} else {
+					runtime.Gosched()
+				}
+			}
+			for n := 0; n < items; {
+				if v, ok := u.Pop(); ok {
+					utotal += v
+					n++
+				} else {
+					runtime.Gosched()
+				}
+			}
+			sum.Store(p, total)
+			usum.Store(p, utotal)
+		}(p)
+	}
+	wg.Wait()
+
+	want := producers * items * (items + 1) / 2
+	for _, m := range []*sync.Map{&sum, &usum} {
+		total := 0
+		m.Range(func(_, v any) bool {
+			total += v.(int)
+			return true
+		})
+		if total != want {
+			t.Fatalf("sum of received items = %d, want %d", total, want)
+		}
+	}
+	if q.Size() != 0 || u.Size() != 0 {
+		t.Fatalf("queues are not empty")
+	}
+}
+
+func BenchmarkMPMCQueue(b *testing.B) {
+	q := NewMPMCQueue[int](1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if q.Offer(1) {
+				q.Poll()
+			}
+		}
+	})
+}
+
+func BenchmarkUnboundedMPMCQueue(b *testing.B) {
+	q := NewUnboundedMPMCQueue[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Push(1)
+			q.Pop()
+		}
+	})
+}
+
+func BenchmarkChannelQueue(b *testing.B) {
+	q := make(chan int, 1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			select {
+			case q <- 1:
+				<-q
+			default:
+			}
+		}
+	})
+}
+
+func BenchmarkMutexLinkedListQueue(b *testing.B) {
+	var lock sync.Mutex
+	q := gotypes.NewLinkedList[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			lock.Lock()
+			q.PushBack(1)
+			lock.Unlock()
+			lock.Lock()
+			q.PopFront()
+			lock.Unlock()
+		}
+	})
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"hash/maphash"
+	"math"
+	"reflect"
+	"unsafe"
+
+	"github.com/lynnplus/gotypes/constraints"
+)
+
+// newHasher returns a randomly seeded hash function for keys of type K.
+// The kind of K is inspected once, numbers are hashed from their bits and strings with hash/maphash.
+// Keys that are equal with == have the same hash, so 0 and -0 hash alike.
+func newHasher[K constraints.Basic]() func(key K) uint64 {
+	seed := maphash.MakeSeed()
+	var h maphash.Hash
+	h.SetSeed(seed)
+	salt := h.Sum64()
+
+	var zero K
+	switch reflect.TypeOf(zero).Kind() {
+	case reflect.String:
+		return func(key K) uint64 {
+			return maphash.String(seed, *(*string)(unsafe.Pointer(&key)))
+		}
+	case reflect.Float32:
+		return func(key K) uint64 {
+			f := *(*float32)(unsafe.Pointer(&key))
+			if f == 0 {
+				return mix64(salt)
+			}
+			return mix64(uint64(math.Float32bits(f)) ^ salt)
+		}
+	case reflect.Float64:
+		return func(key K) uint64 {
+			f := *(*float64)(unsafe.Pointer(&key))
+			if f == 0 {
+				return mix64(salt)
+			}
+			return mix64(math.Float64bits(f) ^ salt)
+		}
+	}
+	switch unsafe.Sizeof(zero) {
+	case 1:
+		return func(key K) uint64 {
+			return mix64(uint64(*(*uint8)(unsafe.Pointer(&key))) ^ salt)
+		}
+	case 2:
+		return func(key K) uint64 {
+			return mix64(uint64(*(*uint16)(unsafe.Pointer(&key))) ^ salt)
+		}
+	case 4:
+		return func(key K) uint64 {
+			return mix64(uint64(*(*uint32)(unsafe.Pointer(&key))) ^ salt)
+		}
+	default:
+		return func(key K) uint64 {
+			return mix64(*(*uint64)(unsafe.Pointer(&key)) ^ salt)
+		}
+	}
+}
+
+// mix64 is the finalizer of splitmix64, it spreads every input bit over the output.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
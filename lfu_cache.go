@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+type lfuEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	bucket  *ListElement[*lfuBucket[K, V]]
+	element *ListElement[*lfuEntry[K, V]]
+}
+
+// lfuBucket holds the entries with the same access count from the least to the most recently used
+type lfuBucket[K comparable, V any] struct {
+	count   uint64
+	entries *LinkedList[*lfuEntry[K, V]]
+}
+
+// lfuPolicy evicts the least frequently used entry, ties are broken by evicting the least recently used one.
+// Buckets are kept in ascending order of their count, so every operation is O(1).
+type lfuPolicy[K comparable, V any] struct {
+	bm      map[K]*lfuEntry[K, V]
+	buckets *LinkedList[*lfuBucket[K, V]]
+	size    int
+}
+
+// NewLFUCache return a Cache that evicts the least frequently used entry,
+// onEvict is called for each entry evicted to make room and may be nil.
+// It panics if capacity is not positive.
+func NewLFUCache[K comparable, V any](capacity int, onEvict func(key K, value V)) Cache[K, V] {
+	checkCacheCapacity(capacity)
+	return newPolicyCache[K, V](&lfuPolicy[K, V]{
+		bm:      make(map[K]*lfuEntry[K, V]),
+		buckets: NewLinkedList[*lfuBucket[K, V]](),
+		size:    capacity,
+	}, onEvict)
+}
+
+func (p *lfuPolicy[K, V]) get(key K) (V, bool) {
+	e, ok := p.bm[key]
+	if !ok {
+		return *new(V), false
+	}
+	p.touch(e)
+	return e.value, true
+}
+
+func (p *lfuPolicy[K, V]) peek(key K) (V, bool) {
+	e, ok := p.bm[key]
+	if !ok {
+		return *new(V), false
+	}
+	return e.value, true
+}
+
+func (p *lfuPolicy[K, V]) add(key K, value V, evict func(key K, value V)) {
+	if e, ok := p.bm[key]; ok {
+		e.value = value
+		p.touch(e)
+		return
+	}
+	if len(p.bm) >= p.size {
+		victim := p.buckets.Front().Value.entries.Front().Value
+		p.unlink(victim)
+		evict(victim.key, victim.value)
+	}
+	first := p.buckets.Front()
+	if first == nil || first.Value.count != 1 {
+		p.buckets.PushFront(&lfuBucket[K, V]{count: 1, entries: NewLinkedList[*lfuEntry[K, V]]()})
+		first = p.buckets.Front()
+	}
+	e := &lfuEntry[K, V]{key: key, value: value, bucket: first}
+	first.Value.entries.PushBack(e)
+	e.element = first.Value.entries.Back()
+	p.bm[key] = e
+}
+
+func (p *lfuPolicy[K, V]) remove(key K) (V, bool) {
+	e, ok := p.bm[key]
+	if !ok {
+		return *new(V), false
+	}
+	p.unlink(e)
+	return e.value, true
+}
+
+// rangeEntries visits entries from the least to the most frequently used
+func (p *lfuPolicy[K, V]) rangeEntries(f func(key K, value V) bool) {
+	for b := p.buckets.Front(); b != nil; b = b.Next() {
+		for e := b.Value.entries.Front(); e != nil; e = e.Next() {
+			if !f(e.Value.key, e.Value.value) {
+				return
+			}
+		}
+	}
+}
+
+func (p *lfuPolicy[K, V]) len() int {
+	return len(p.bm)
+}
+
+func (p *lfuPolicy[K, V]) capacity() int {
+	return p.size
+}
+
+func (p *lfuPolicy[K, V]) clear() {
+	p.bm = make(map[K]*lfuEntry[K, V])
+	p.buckets.RemoveAll()
+}
+
+// touch moves the entry to the bucket of the next count
+func (p *lfuPolicy[K, V]) touch(e *lfuEntry[K, V]) {
+	cur := e.bucket
+	next := cur.Next()
+	if next == nil || next.Value.count != cur.Value.count+1 {
+		next = p.buckets.InsertAfter(&lfuBucket[K, V]{
+			count:   cur.Value.count + 1,
+			entries: NewLinkedList[*lfuEntry[K, V]](),
+		}, cur)
+	}
+	cur.Value.entries.RemoveElement(e.element)
+	next.Value.entries.PushBack(e)
+	e.element = next.Value.entries.Back()
+	e.bucket = next
+	if cur.Value.entries.Empty() {
+		p.buckets.RemoveElement(cur)
+	}
+}
+
+func (p *lfuPolicy[K, V]) unlink(e *lfuEntry[K, V]) {
+	delete(p.bm, e.key)
+	b := e.bucket
+	b.Value.entries.RemoveElement(e.element)
+	if b.Value.entries.Empty() {
+		p.buckets.RemoveElement(b)
+	}
+}
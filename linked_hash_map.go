@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+var (
+	_ Map[int, int]    = (*LinkedHashMap[int, int])(nil)
+	_ Enumerable[bool] = (*LinkedHashMap[int, bool])(nil)
+)
+
+type linkedHashEntry[K comparable, V any] struct {
+	value   V
+	element *ListElement[K]
+}
+
+// LinkedHashMap implements the Map[K,V] interface with a predictable iteration order.
+// By default entries are ordered by insertion, re-storing a key does not change its position.
+// A map created by NewAccessOrderLinkedHashMap orders entries from least to most recently accessed instead.
+type LinkedHashMap[K comparable, V any] struct {
+	bm          map[K]*linkedHashEntry[K, V]
+	order       *LinkedList[K]
+	accessOrder bool
+}
+
+// NewLinkedHashMap return a LinkedHashMap in insertion order
+func NewLinkedHashMap[K comparable, V any]() *LinkedHashMap[K, V] {
+	return &LinkedHashMap[K, V]{
+		bm:    make(map[K]*linkedHashEntry[K, V]),
+		order: NewLinkedList[K](),
+	}
+}
+
+// NewAccessOrderLinkedHashMap return a LinkedHashMap in access order,
+// Get, Load and Store move the accessed key to the end.
+func NewAccessOrderLinkedHashMap[K comparable, V any]() *LinkedHashMap[K, V] {
+	m := NewLinkedHashMap[K, V]()
+	m.accessOrder = true
+	return m
+}
+
+func (m *LinkedHashMap[K, V]) Get(key K) V {
+	val, _ := m.Load(key)
+	return val
+}
+
+func (m *LinkedHashMap[K, V]) Exist(key K) (ok bool) {
+	_, ok = m.bm[key]
+	return ok
+}
+
+func (m *LinkedHashMap[K, V]) Store(key K, value V) {
+	if e, ok := m.bm[key]; ok {
+		e.value = value
+		if m.accessOrder {
+			m.order.MoveToBack(e.element)
+		}
+		return
+	}
+	m.order.PushBack(key)
+	m.bm[key] = &linkedHashEntry[K, V]{value: value, element: m.order.Back()}
+}
+
+func (m *LinkedHashMap[K, V]) Load(key K) (value V, ok bool) {
+	e, ok := m.bm[key]
+	if !ok {
+		return value, false
+	}
+	if m.accessOrder {
+		m.order.MoveToBack(e.element)
+	}
+	return e.value, true
+}
+
+// Peek returns the value for the key without changing the access order.
+func (m *LinkedHashMap[K, V]) Peek(key K) (value V, ok bool) {
+	e, ok := m.bm[key]
+	if !ok {
+		return value, false
+	}
+	return e.value, true
+}
+
+// MoveToEnd moves the key to the end of the iteration order, and reports whether the key exists.
+func (m *LinkedHashMap[K, V]) MoveToEnd(key K) bool {
+	e, ok := m.bm[key]
+	if ok {
+		m.order.MoveToBack(e.element)
+	}
+	return ok
+}
+
+// First returns the first entry in iteration order, ok is false if the map is empty.
+func (m *LinkedHashMap[K, V]) First() (key K, value V, ok bool) {
+	if e := m.order.Front(); e != nil {
+		return e.Value, m.bm[e.Value].value, true
+	}
+	return key, value, false
+}
+
+// Last returns the last entry in iteration order, ok is false if the map is empty.
+func (m *LinkedHashMap[K, V]) Last() (key K, value V, ok bool) {
+	if e := m.order.Back(); e != nil {
+		return e.Value, m.bm[e.Value].value, true
+	}
+	return key, value, false
+}
+
+// Range calls f for each entry in iteration order, f must not modify the map.
+func (m *LinkedHashMap[K, V]) Range(f func(key K, value V) bool) {
+	for e := m.order.Front(); e != nil; e = e.Next() {
+		if !f(e.Value, m.bm[e.Value].value) {
+			break
+		}
+	}
+}
+
+// ReverseRange is like Range but iterates from the last entry to the first.
+func (m *LinkedHashMap[K, V]) ReverseRange(f func(key K, value V) bool) {
+	for e := m.order.Back(); e != nil; e = e.Prev() {
+		if !f(e.Value, m.bm[e.Value].value) {
+			break
+		}
+	}
+}
+
+func (m *LinkedHashMap[K, V]) Each(f func(key K, value V)) {
+	m.Range(func(k1 K, v1 V) bool {
+		f(k1, v1)
+		return true
+	})
+}
+
+func (m *LinkedHashMap[K, V]) EachValue(f func(value V)) {
+	m.Range(func(_ K, v1 V) bool {
+		f(v1)
+		return true
+	})
+}
+
+func (m *LinkedHashMap[K, V]) Keys() []K {
+	return m.order.Values()
+}
+
+func (m *LinkedHashMap[K, V]) Values() []V {
+	r := make([]V, 0, len(m.bm))
+	m.EachValue(func(v V) {
+		r = append(r, v)
+	})
+	return r
+}
+
+func (m *LinkedHashMap[K, V]) Size() int {
+	return len(m.bm)
+}
+
+func (m *LinkedHashMap[K, V]) Delete(key K) {
+	if e, ok := m.bm[key]; ok {
+		m.order.RemoveElement(e.element)
+		delete(m.bm, key)
+	}
+}
+
+func (m *LinkedHashMap[K, V]) DeleteAll() {
+	m.bm = make(map[K]*linkedHashEntry[K, V])
+	m.order.RemoveAll()
+}
+
+func (m *LinkedHashMap[K, V]) Data() map[K]V {
+	r := make(map[K]V, len(m.bm))
+	for k, e := range m.bm {
+		r[k] = e.value
+	}
+	return r
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLinkedHashMapInsertionOrder(t *testing.T) {
+	m := NewLinkedHashMap[string, int]()
+	if _, _, ok := m.First(); ok {
+		t.Errorf("First() on an empty map found an entry")
+	}
+	if _, _, ok := m.Last(); ok {
+		t.Errorf("Last() on an empty map found an entry")
+	}
+	for i, k := range []string{"a", "b", "c", "d"} {
+		m.Store(k, i)
+	}
+	m.Store("a", 10)
+	m.Get("b")
+	if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(m.Keys(), want) {
+		t.Fatalf("Keys() = %v, want %v", m.Keys(), want)
+	}
+	if !m.MoveToEnd("b") || m.MoveToEnd("x") {
+		t.Errorf("MoveToEnd mismatch")
+	}
+	if want := []string{"a", "c", "d", "b"}; !reflect.DeepEqual(m.Keys(), want) {
+		t.Fatalf("Keys() = %v, want %v", m.Keys(), want)
+	}
+
+	m.Delete("a")
+	m.Delete("b")
+	if k, v, ok := m.First(); !ok || k != "c" || v != 2 {
+		t.Errorf("First() = %q, %v, %v after deleting the head", k, v, ok)
+	}
+	if k, v, ok := m.Last(); !ok || k != "d" || v != 3 {
+		t.Errorf("Last() = %q, %v, %v after deleting the tail", k, v, ok)
+	}
+	if want := []int{2, 3}; !reflect.DeepEqual(m.Values(), want) {
+		t.Errorf("Values() = %v, want %v", m.Values(), want)
+	}
+
+	var visited []string
+	m.Store("e", 4)
+	m.Range(func(key string, _ int) bool {
+		visited = append(visited, key)
+		return key != "d"
+	})
+	if want := []string{"c", "d"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("Range visited %v, want %v", visited, want)
+	}
+	visited = nil
+	m.ReverseRange(func(key string, _ int) bool {
+		visited = append(visited, key)
+		return false
+	})
+	if want := []string{"e"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("ReverseRange visited %v, want %v", visited, want)
+	}
+
+	m.DeleteAll()
+	if m.Size() != 0 || len(m.Keys()) != 0 {
+		t.Errorf("map is not empty after DeleteAll")
+	}
+}
+
+func TestLinkedHashMapAccessOrder(t *testing.T) {
+	m := NewAccessOrderLinkedHashMap[int, string]()
+	for i, v := range []string{"a", "b", "c", "d"} {
+		m.Store(i, v)
+	}
+	m.Get(0)
+	m.Load(1)
+	m.Store(2, "C")
+	if v, ok := m.Peek(3); !ok || v != "d" {
+		t.Errorf("Peek(3) = %q, %v", v, ok)
+	}
+	m.Exist(3)
+	if want := []int{3, 0, 1, 2}; !reflect.DeepEqual(m.Keys(), want) {
+		t.Fatalf("Keys() = %v, want %v", m.Keys(), want)
+	}
+	if _, ok := m.Load(9); ok {
+		t.Errorf("Load(9) found a value")
+	}
+	if k, v, ok := m.Last(); !ok || k != 2 || v != "C" {
+		t.Errorf("Last() = %v, %q, %v", k, v, ok)
+	}
+}
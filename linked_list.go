@@ -20,15 +20,33 @@ var (
 	_ Array[int] = (*LinkedList[int])(nil)
 )
 
-type listElement[T comparable] struct {
-	value T
-	prev  *listElement[T]
-	next  *listElement[T]
+// ListElement is an element of a LinkedList, it stays valid until it is removed from the list.
+type ListElement[T comparable] struct {
+	Value T
+	prev  *ListElement[T]
+	next  *ListElement[T]
+	list  *LinkedList[T]
+}
+
+// Next returns the next list element or nil.
+func (e *ListElement[T]) Next() *ListElement[T] {
+	if e.list == nil {
+		return nil
+	}
+	return e.next
+}
+
+// Prev returns the previous list element or nil.
+func (e *ListElement[T]) Prev() *ListElement[T] {
+	if e.list == nil {
+		return nil
+	}
+	return e.prev
 }
 
 type LinkedList[T comparable] struct {
-	first *listElement[T]
-	last  *listElement[T]
+	first *ListElement[T]
+	last  *ListElement[T]
 	size  int
 }
 
@@ -41,15 +59,7 @@ func NewLinkedList[T comparable](values ...T) *LinkedList[T] {
 
 func (list *LinkedList[T]) Add(values ...T) {
 	for _, value := range values {
-		newElement := &listElement[T]{value: value, prev: list.last}
-		if list.size == 0 {
-			list.first = newElement
-			list.last = newElement
-		} else {
-			list.last.next = newElement
-			list.last = newElement
-		}
-		list.size++
+		list.insertAfter(value, list.last)
 	}
 }
 
@@ -60,76 +70,128 @@ func (list *LinkedList[T]) Set(index int, v T) {
 		}
 		return
 	}
-	var temp *listElement[T]
-	if index > list.size-index {
-		temp = list.last
-		for i := list.size - 1; i != index; i, temp = i-1, temp.prev {
-		}
-	} else {
-		temp = list.first
-		for i := 0; i != index; i, temp = i+1, temp.next {
-		}
-	}
-	temp.value = v
+	list.elementAt(index).Value = v
 }
 
 func (list *LinkedList[T]) Get(index int) (T, bool) {
 	if !list.checkInRange(index) {
 		return *new(T), false
 	}
+	return list.elementAt(index).Value, true
+}
 
-	if list.size-index < index {
-		element := list.last
-		for e := list.size - 1; e != index; e, element = e-1, element.prev {
-		}
-		return element.value, true
+func (list *LinkedList[T]) Remove(index int) {
+	if !list.checkInRange(index) {
+		return
 	}
-	element := list.first
-	for e := 0; e != index; e, element = e+1, element.next {
+	list.unlink(list.elementAt(index))
+}
+
+func (list *LinkedList[T]) RemoveAll() {
+	for e := list.first; e != nil; {
+		next := e.next
+		e.prev, e.next, e.list = nil, nil, nil
+		e = next
 	}
-	return element.value, true
+	list.size = 0
+	list.first = nil
+	list.last = nil
 }
 
-func (list *LinkedList[T]) Remove(index int) {
-	if !list.checkInRange(index) {
+// Front returns the first element of the list or nil if the list is empty.
+func (list *LinkedList[T]) Front() *ListElement[T] {
+	return list.first
+}
+
+// Back returns the last element of the list or nil if the list is empty.
+func (list *LinkedList[T]) Back() *ListElement[T] {
+	return list.last
+}
+
+// InsertBefore inserts a new element with value v immediately before mark and returns it.
+// The list is not modified and nil is returned if mark is not an element of the list.
+func (list *LinkedList[T]) InsertBefore(v T, mark *ListElement[T]) *ListElement[T] {
+	if mark == nil || mark.list != list {
+		return nil
+	}
+	return list.insertAfter(v, mark.prev)
+}
+
+// InsertAfter inserts a new element with value v immediately after mark and returns it.
+// The list is not modified and nil is returned if mark is not an element of the list.
+func (list *LinkedList[T]) InsertAfter(v T, mark *ListElement[T]) *ListElement[T] {
+	if mark == nil || mark.list != list {
+		return nil
+	}
+	return list.insertAfter(v, mark)
+}
+
+// MoveToFront moves element e to the front of the list,
+// the list is not modified if e is not an element of the list.
+func (list *LinkedList[T]) MoveToFront(e *ListElement[T]) {
+	if e == nil || e.list != list || list.first == e {
 		return
 	}
+	list.detach(e)
+	list.attachAfter(e, nil)
+}
 
-	if list.size == 1 {
-		list.RemoveAll()
+// MoveToBack moves element e to the back of the list,
+// the list is not modified if e is not an element of the list.
+func (list *LinkedList[T]) MoveToBack(e *ListElement[T]) {
+	if e == nil || e.list != list || list.last == e {
 		return
 	}
+	list.detach(e)
+	list.attachAfter(e, list.last)
+}
 
-	var temp *listElement[T]
-	if index > list.size-index {
-		temp = list.last
-		for i := list.size - 1; i != index; i, temp = i-1, temp.prev {
-		}
-	} else {
-		temp = list.first
-		for i := 0; i != index; i, temp = i+1, temp.next {
-		}
+// RemoveElement removes element e from the list if it is an element of the list,
+// and returns the element value.
+func (list *LinkedList[T]) RemoveElement(e *ListElement[T]) T {
+	if e == nil {
+		return *new(T)
 	}
-	if temp == list.first {
-		list.first = temp.next
+	if e.list == list {
+		list.unlink(e)
 	}
-	if temp == list.last {
-		list.last = temp.prev
+	return e.Value
+}
+
+func (list *LinkedList[T]) PushFront(v T) {
+	list.insertAfter(v, nil)
+}
+
+func (list *LinkedList[T]) PushBack(v T) {
+	list.insertAfter(v, list.last)
+}
+
+func (list *LinkedList[T]) PopFront() (v T, ok bool) {
+	if list.first == nil {
+		return v, false
 	}
-	if temp.prev != nil {
-		temp.prev.next = temp.next
+	return list.RemoveElement(list.first), true
+}
+
+func (list *LinkedList[T]) PopBack() (v T, ok bool) {
+	if list.last == nil {
+		return v, false
 	}
-	if temp.next != nil {
-		temp.next.prev = temp.prev
+	return list.RemoveElement(list.last), true
+}
+
+func (list *LinkedList[T]) PeekFront() (v T, ok bool) {
+	if list.first == nil {
+		return v, false
 	}
-	temp = nil
-	list.size--
+	return list.first.Value, true
 }
 
-func (list *LinkedList[T]) RemoveAll() {
-	list.size = 0
-	list.first = nil
-	list.last = nil
+func (list *LinkedList[T]) PeekBack() (v T, ok bool) {
+	if list.last == nil {
+		return v, false
+	}
+	return list.last.Value, true
 }
 
 func (list *LinkedList[T]) IndexOf(value T) int {
@@ -149,7 +211,7 @@ func (list *LinkedList[T]) IndexOf(value T) int {
 
 func (list *LinkedList[T]) Range(f func(index int, value T) bool) {
 	for i, ele := 0, list.first; ele != nil; i, ele = i+1, ele.next {
-		if !f(i, ele.value) {
+		if !f(i, ele.Value) {
 			break
 		}
 	}
@@ -157,7 +219,7 @@ func (list *LinkedList[T]) Range(f func(index int, value T) bool) {
 
 func (list *LinkedList[T]) ReverseRange(f func(index int, value T) bool) {
 	for i, ele := list.size-1, list.last; ele != nil; i, ele = i-1, ele.prev {
-		if !f(i, ele.value) {
+		if !f(i, ele.Value) {
 			break
 		}
 	}
@@ -207,3 +269,62 @@ func (list *LinkedList[T]) Size() int {
 func (list *LinkedList[T]) checkInRange(index int) bool {
 	return index >= 0 && index < list.size
 }
+
+// elementAt walks to the element at index from the nearest end, index must be in range.
+func (list *LinkedList[T]) elementAt(index int) *ListElement[T] {
+	var temp *ListElement[T]
+	if index > list.size-index {
+		temp = list.last
+		for i := list.size - 1; i != index; i, temp = i-1, temp.prev {
+		}
+	} else {
+		temp = list.first
+		for i := 0; i != index; i, temp = i+1, temp.next {
+		}
+	}
+	return temp
+}
+
+// insertAfter inserts a new element after mark, or at the front if mark is nil.
+func (list *LinkedList[T]) insertAfter(v T, mark *ListElement[T]) *ListElement[T] {
+	e := &ListElement[T]{Value: v}
+	list.attachAfter(e, mark)
+	return e
+}
+
+func (list *LinkedList[T]) attachAfter(e, mark *ListElement[T]) {
+	e.list = list
+	e.prev = mark
+	if mark == nil {
+		e.next = list.first
+		list.first = e
+	} else {
+		e.next = mark.next
+		mark.next = e
+	}
+	if e.next == nil {
+		list.last = e
+	} else {
+		e.next.prev = e
+	}
+	list.size++
+}
+
+func (list *LinkedList[T]) detach(e *ListElement[T]) {
+	if e.prev == nil {
+		list.first = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		list.last = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+	list.size--
+}
+
+func (list *LinkedList[T]) unlink(e *ListElement[T]) {
+	list.detach(e)
+	e.prev, e.next, e.list = nil, nil, nil
+}
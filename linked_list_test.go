@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLinkedListElements(t *testing.T) {
+	list := NewLinkedList(1, 2, 3)
+	front, back := list.Front(), list.Back()
+	if front.Value != 1 || back.Value != 3 || front.Prev() != nil || back.Next() != nil {
+		t.Fatalf("unexpected front/back elements")
+	}
+
+	list.InsertBefore(0, front)
+	four := list.InsertAfter(4, back)
+	list.MoveToFront(four)
+	list.MoveToBack(front)
+	if want := []int{4, 0, 2, 3, 1}; !reflect.DeepEqual(list.Values(), want) {
+		t.Fatalf("Values() = %v, want %v", list.Values(), want)
+	}
+
+	if v := list.RemoveElement(four); v != 4 || list.Size() != 4 {
+		t.Fatalf("RemoveElement = %v, size %d", v, list.Size())
+	}
+	if list.InsertAfter(5, four) != nil || list.Size() != 4 {
+		t.Fatalf("a removed element must not be used as a mark")
+	}
+
+	var reversed []int
+	for e := list.Back(); e != nil; e = e.Prev() {
+		reversed = append(reversed, e.Value)
+	}
+	if want := []int{1, 3, 2, 0}; !reflect.DeepEqual(reversed, want) {
+		t.Fatalf("reverse walk = %v, want %v", reversed, want)
+	}
+	list.Remove(1)
+	if v, _ := list.Get(1); v != 3 || list.Back().Value != 1 {
+		t.Fatalf("Remove(1) left %v", list.Values())
+	}
+}
@@ -0,0 +1,329 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import "sync"
+
+var (
+	_ Container                   = (*LRUCache[int, int])(nil)
+	_ Cache[int, int]             = (*SafeLRUCache[int, int])(nil)
+	_ Enumerable[bool]            = (*SafeLRUCache[int, bool])(nil)
+	_ Enumerable[bool]            = (*LRUCache[int, bool])(nil)
+	_ EnumerableWithKey[int, int] = (*LRUCache[int, int])(nil)
+)
+
+// LRUCache is a fixed capacity cache that evicts the least recently used entry when it is full.
+// It is backed by a LinkedHashMap in access order.
+type LRUCache[K comparable, V any] struct {
+	entries  *LinkedHashMap[K, V]
+	capacity int
+	onEvict  func(key K, value V)
+}
+
+// NewLRUCache return an LRUCache that holds at most capacity entries,
+// onEvict is called for each entry evicted to make room and may be nil.
+// It panics if capacity is not positive.
+func NewLRUCache[K comparable, V any](capacity int, onEvict func(key K, value V)) *LRUCache[K, V] {
+	if capacity <= 0 {
+		panic("gotypes: LRUCache capacity must be positive")
+	}
+	return &LRUCache[K, V]{
+		entries:  NewAccessOrderLinkedHashMap[K, V](),
+		capacity: capacity,
+		onEvict:  onEvict,
+	}
+}
+
+// Get returns the value for the key and marks it as the most recently used
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	return c.entries.Load(key)
+}
+
+// Peek returns the value for the key without changing its recency
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	return c.entries.Peek(key)
+}
+
+// Contains reports whether the key is in the cache without changing its recency
+func (c *LRUCache[K, V]) Contains(key K) bool {
+	return c.entries.Exist(key)
+}
+
+// Add stores the value as the most recently used entry, and reports whether an entry was evicted.
+func (c *LRUCache[K, V]) Add(key K, value V) (evicted bool) {
+	c.entries.Store(key, value)
+	return c.evict(c.capacity) > 0
+}
+
+// Remove removes the key and reports whether it was present
+func (c *LRUCache[K, V]) Remove(key K) bool {
+	if !c.entries.Exist(key) {
+		return false
+	}
+	c.entries.Delete(key)
+	return true
+}
+
+// Oldest returns the least recently used entry without changing its recency
+func (c *LRUCache[K, V]) Oldest() (key K, value V, ok bool) {
+	return c.entries.First()
+}
+
+// Resize changes the capacity and returns the number of entries evicted to fit it.
+// It panics if capacity is not positive.
+func (c *LRUCache[K, V]) Resize(capacity int) (evicted int) {
+	if capacity <= 0 {
+		panic("gotypes: LRUCache capacity must be positive")
+	}
+	c.capacity = capacity
+	return c.evict(capacity)
+}
+
+// Keys returns all keys from the least to the most recently used
+func (c *LRUCache[K, V]) Keys() []K {
+	return c.entries.Keys()
+}
+
+// Values returns all values from the least to the most recently used
+func (c *LRUCache[K, V]) Values() []V {
+	return c.entries.Values()
+}
+
+// Each calls f for each entry from the least to the most recently used without changing recency
+func (c *LRUCache[K, V]) Each(f func(key K, value V)) {
+	c.entries.Each(f)
+}
+
+func (c *LRUCache[K, V]) EachValue(f func(value V)) {
+	c.entries.EachValue(f)
+}
+
+func (c *LRUCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+func (c *LRUCache[K, V]) Empty() bool {
+	return c.entries.Size() == 0
+}
+
+func (c *LRUCache[K, V]) Size() int {
+	return c.entries.Size()
+}
+
+// RemoveAll removes all entries without calling the eviction callback
+func (c *LRUCache[K, V]) RemoveAll() {
+	c.entries.DeleteAll()
+}
+
+func (c *LRUCache[K, V]) evict(capacity int) int {
+	evicted := 0
+	for c.entries.Size() > capacity {
+		key, value, _ := c.entries.First()
+		c.entries.Delete(key)
+		evicted++
+		if c.onEvict != nil {
+			c.onEvict(key, value)
+		}
+	}
+	return evicted
+}
+
+// SafeLRUCache is an LRUCache guarded by a sync.Mutex, it implements the Cache[K,V] interface.
+// Load and Get mark the key as the most recently used, other reads do not change recency.
+// The eviction callback is called after the lock is released.
+type SafeLRUCache[K comparable, V any] struct {
+	lock    *sync.Mutex
+	cache   *LRUCache[K, V]
+	onEvict func(key K, value V)
+	evicted []evictedEntry[K, V]
+	stats   CacheStats
+}
+
+// NewSafeLRUCache return a SafeLRUCache that holds at most capacity entries,
+// onEvict is called for each entry evicted to make room and may be nil.
+// It panics if capacity is not positive.
+func NewSafeLRUCache[K comparable, V any](capacity int, onEvict func(key K, value V)) *SafeLRUCache[K, V] {
+	c := &SafeLRUCache[K, V]{
+		lock:    new(sync.Mutex),
+		onEvict: onEvict,
+	}
+	c.cache = NewLRUCache[K, V](capacity, func(key K, value V) {
+		c.stats.Evictions++
+		if c.onEvict != nil {
+			c.evicted = append(c.evicted, evictedEntry[K, V]{key, value})
+		}
+	})
+	return c
+}
+
+// unlock releases the lock and then calls the eviction callback for the entries evicted while it was held
+func (c *SafeLRUCache[K, V]) unlock() {
+	evicted := c.evicted
+	c.evicted = nil
+	c.lock.Unlock()
+	for _, e := range evicted {
+		c.onEvict(e.key, e.value)
+	}
+}
+
+func (c *SafeLRUCache[K, V]) Get(key K) V {
+	val, _ := c.Load(key)
+	return val
+}
+
+func (c *SafeLRUCache[K, V]) Exist(key K) (ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.cache.Contains(key)
+}
+
+func (c *SafeLRUCache[K, V]) Store(key K, value V) {
+	c.Add(key, value)
+}
+
+// Add stores the value as the most recently used entry, and reports whether an entry was evicted.
+func (c *SafeLRUCache[K, V]) Add(key K, value V) (evicted bool) {
+	c.lock.Lock()
+	defer c.unlock()
+	return c.cache.Add(key, value)
+}
+
+func (c *SafeLRUCache[K, V]) Load(key K) (value V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	value, ok = c.cache.Get(key)
+	if ok {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+	return value, ok
+}
+
+// Peek returns the value for the key without changing its recency
+func (c *SafeLRUCache[K, V]) Peek(key K) (value V, ok bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.cache.Peek(key)
+}
+
+// Range calls f for each entry from the least to the most recently used while holding the lock,
+// f must not modify the cache.
+func (c *SafeLRUCache[K, V]) Range(f func(key K, value V) bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cache.entries.Range(f)
+}
+
+func (c *SafeLRUCache[K, V]) Each(f func(key K, value V)) {
+	c.Range(func(k K, v V) bool {
+		f(k, v)
+		return true
+	})
+}
+
+func (c *SafeLRUCache[K, V]) EachValue(f func(value V)) {
+	c.Range(func(_ K, v V) bool {
+		f(v)
+		return true
+	})
+}
+
+// Keys returns all keys from the least to the most recently used
+func (c *SafeLRUCache[K, V]) Keys() []K {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.cache.Keys()
+}
+
+// Values returns all values from the least to the most recently used
+func (c *SafeLRUCache[K, V]) Values() []V {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.cache.Values()
+}
+
+func (c *SafeLRUCache[K, V]) Size() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.cache.Size()
+}
+
+func (c *SafeLRUCache[K, V]) Capacity() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.cache.Capacity()
+}
+
+func (c *SafeLRUCache[K, V]) Stats() CacheStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.stats
+}
+
+// Resize changes the capacity and returns the number of entries evicted to fit it.
+func (c *SafeLRUCache[K, V]) Resize(capacity int) (evicted int) {
+	c.lock.Lock()
+	defer c.unlock()
+	return c.cache.Resize(capacity)
+}
+
+func (c *SafeLRUCache[K, V]) Delete(key K) {
+	c.Remove(key)
+}
+
+// Remove removes the key and reports whether it was present
+func (c *SafeLRUCache[K, V]) Remove(key K) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.cache.Remove(key)
+}
+
+// DeleteAll removes all entries without calling the eviction callback
+func (c *SafeLRUCache[K, V]) DeleteAll() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cache.RemoveAll()
+}
+
+func (c *SafeLRUCache[K, V]) Data() map[K]V {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.cache.entries.Data()
+}
+
+func (c *SafeLRUCache[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	c.lock.Lock()
+	defer c.unlock()
+	if temp, ok := c.cache.Get(key); ok {
+		c.stats.Hits++
+		return temp, true
+	}
+	c.stats.Misses++
+	c.cache.Add(key, value)
+	return value, false
+}
+
+func (c *SafeLRUCache[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	value, loaded = c.cache.Peek(key)
+	if loaded {
+		c.cache.Remove(key)
+	}
+	return value, loaded
+}
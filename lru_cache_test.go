@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestLRUCache(t *testing.T) {
+	var evicted []int
+	c := NewLRUCache[int, string](3, func(key int, _ string) {
+		evicted = append(evicted, key)
+	})
+	for i, v := range []string{"a", "b", "c"} {
+		if c.Add(i, v) {
+			t.Fatalf("Add(%d) evicted an entry", i)
+		}
+	}
+	if v, ok := c.Get(0); !ok || v != "a" {
+		t.Errorf("Get(0) = %q, %v", v, ok)
+	}
+	if v, ok := c.Peek(1); !ok || v != "b" {
+		t.Errorf("Peek(1) = %q, %v", v, ok)
+	}
+	if want := []int{1, 2, 0}; !reflect.DeepEqual(c.Keys(), want) {
+		t.Errorf("Keys() = %v, want %v", c.Keys(), want)
+	}
+	if !c.Add(3, "d") {
+		t.Errorf("Add(3) did not evict")
+	}
+	if want := []int{1}; !reflect.DeepEqual(evicted, want) {
+		t.Errorf("evicted = %v, want %v", evicted, want)
+	}
+	if c.Add(0, "A") || c.Size() != 3 {
+		t.Errorf("updating a key evicted an entry")
+	}
+	if n := c.Resize(1); n != 2 || c.Size() != 1 {
+		t.Errorf("Resize(1) = %d, size %d", n, c.Size())
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(evicted, want) {
+		t.Errorf("evicted = %v, want %v", evicted, want)
+	}
+	if !c.Remove(0) || c.Remove(0) || !c.Empty() {
+		t.Errorf("Remove(0) mismatch")
+	}
+}
+
+func TestSafeLRUCache(t *testing.T) {
+	var mu sync.Mutex
+	evicted := 0
+	c := NewSafeLRUCache[int, int](64, func(int, int) {
+		mu.Lock()
+		evicted++
+		mu.Unlock()
+	})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				c.Store(g*1000+i, i)
+				c.Load(g*1000 + i/2)
+				c.LoadOrStore(i, i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	if c.Size() != 64 {
+		t.Errorf("size = %d, want 64", c.Size())
+	}
+	if evicted < 8*1000-64 {
+		t.Errorf("evicted = %d, want at least %d", evicted, 8*1000-64)
+	}
+}
@@ -16,9 +16,7 @@
 
 package gotypes
 
-import "github.com/lynnplus/gotypes/constraints"
-
-type Map[K constraints.Basic, V any] interface {
+type Map[K comparable, V any] interface {
 	Get(key K) V
 	Exist(key K) (ok bool)
 
@@ -39,17 +37,86 @@ type Map[K constraints.Basic, V any] interface {
 	Data() map[K]V
 }
 
-type SafeMap[K constraints.Basic, V any] interface {
+type SafeMap[K comparable, V any] interface {
 	Map[K, V]
 
 	LoadOrStore(key K, value V) (actual V, loaded bool)
 	LoadAndDelete(key K) (value V, loaded bool)
 }
 
-var _ Enumerable[int] = (*GoMap[string, int])(nil)
+// AtomicMap is a SafeMap with atomic read-modify-write operations on a single key.
+// It is a separate interface on purpose: SafeMap is also implemented by ExpiringMap, SafeTrie and the Cache types,
+// whose expiry and eviction would have to be part of every operation, so code that needs these operations
+// should accept an AtomicMap instead of a SafeMap. RWMutexMap, SyncMap and ShardedMap implement it.
+// The callbacks may run while the map or part of it is locked, they must not access the map.
+// CompareAndSwap and CompareAndDelete compare values with the comparer the map was created with.
+type AtomicMap[K comparable, V any] interface {
+	SafeMap[K, V]
+
+	// Compute stores the value returned by f for the key, or deletes the key if f returns false.
+	// f receives the current value and whether the key was present. It may be called again
+	// if a concurrent write changes the key before its result is stored, so it should have no side effects.
+	// It returns the new value and whether the key is present afterwards.
+	Compute(key K, f func(old V, loaded bool) (value V, keep bool)) (actual V, ok bool)
+	// ComputeIfAbsent returns the existing value for the key if present,
+	// otherwise it stores and returns the value returned by factory. Concurrent callers for the same absent key
+	// call factory at most once, the others wait for it and load its result.
+	ComputeIfAbsent(key K, factory func() V) (actual V, loaded bool)
+	// Merge stores value if the key is absent, otherwise it stores the result of f applied to the old value and value.
+	// It returns the stored value.
+	Merge(key K, value V, f func(old, value V) V) (actual V)
+	// Swap stores the value and returns the previous value if any
+	Swap(key K, value V) (previous V, loaded bool)
+	// CompareAndSwap stores new if the value of the key is equal to old
+	CompareAndSwap(key K, old, new V) (swapped bool)
+	// CompareAndDelete deletes the key if its value is equal to old
+	CompareAndDelete(key K, old V) (deleted bool)
+}
+
+// mergeFunc adapts the merge function of AtomicMap.Merge to a Compute function
+func mergeFunc[V any](value V, f func(old, value V) V) func(old V, loaded bool) (V, bool) {
+	return func(old V, loaded bool) (V, bool) {
+		if !loaded {
+			return value, true
+		}
+		return f(old, value), true
+	}
+}
 
+var (
+	_ Map[string, int] = (GoMap[string, int])(nil)
+	_ Enumerable[int]  = (*GoMap[string, int])(nil)
+)
+
+// GoMap implements the Map[K,V] interface with a plain go map, it is not safe for concurrent use.
 type GoMap[K comparable, V any] map[K]V
 
+func (g GoMap[K, V]) Get(key K) V {
+	return g[key]
+}
+
+func (g GoMap[K, V]) Exist(key K) (ok bool) {
+	_, ok = g[key]
+	return ok
+}
+
+func (g GoMap[K, V]) Store(key K, value V) {
+	g[key] = value
+}
+
+func (g GoMap[K, V]) Load(key K) (value V, ok bool) {
+	value, ok = g[key]
+	return value, ok
+}
+
+func (g GoMap[K, V]) Range(f func(key K, value V) bool) {
+	for k, v := range g {
+		if !f(k, v) {
+			break
+		}
+	}
+}
+
 func (g GoMap[K, V]) Each(f func(key K, value V)) {
 	for k, v := range g {
 		f(k, v)
@@ -65,3 +132,38 @@ func (g GoMap[K, V]) EachValue(f func(value V)) {
 		f(v)
 	})
 }
+
+func (g GoMap[K, V]) Keys() []K {
+	r := make([]K, 0, len(g))
+	for k := range g {
+		r = append(r, k)
+	}
+	return r
+}
+
+func (g GoMap[K, V]) Values() []V {
+	r := make([]V, 0, len(g))
+	for _, v := range g {
+		r = append(r, v)
+	}
+	return r
+}
+
+func (g GoMap[K, V]) Delete(key K) {
+	delete(g, key)
+}
+
+func (g GoMap[K, V]) DeleteAll() {
+	for k := range g {
+		delete(g, k)
+	}
+}
+
+// Data returns a copy of the map
+func (g GoMap[K, V]) Data() map[K]V {
+	r := make(map[K]V, len(g))
+	for k, v := range g {
+		r[k] = v
+	}
+	return r
+}
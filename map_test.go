@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"sort"
+	"testing"
+)
+
+type testUserID string
+
+type testPoint struct {
+	X, Y int
+}
+
+func testMapKeys[K comparable](t *testing.T, name string, m Map[K, int], keys []K) {
+	for i, k := range keys {
+		m.Store(k, i)
+	}
+	if m.Size() != len(keys) || len(m.Keys()) != len(keys) || len(m.Data()) != len(keys) {
+		t.Errorf("%s: size = %d, want %d", name, m.Size(), len(keys))
+	}
+	for i, k := range keys {
+		if v, ok := m.Load(k); !ok || v != i || m.Get(k) != i || !m.Exist(k) {
+			t.Errorf("%s: Load(%v) = %v, %v", name, k, v, ok)
+		}
+	}
+	values := m.Values()
+	sort.Ints(values)
+	for i, v := range values {
+		if v != i {
+			t.Errorf("%s: Values() = %v", name, values)
+			break
+		}
+	}
+	m.Delete(keys[0])
+	if m.Exist(keys[0]) || m.Size() != len(keys)-1 {
+		t.Errorf("%s: Delete(%v) mismatch", name, keys[0])
+	}
+	m.DeleteAll()
+	if m.Size() != 0 {
+		t.Errorf("%s: size = %d after DeleteAll", name, m.Size())
+	}
+}
+
+func TestMapComparableKeys(t *testing.T) {
+	points := []testPoint{{0, 0}, {1, 2}, {2, 1}}
+	for name, m := range map[string]Map[testPoint, int]{
+		"GoMap":         GoMap[testPoint, int]{},
+		"RWMutexMap":    NewRWMutexMap[testPoint, int](),
+		"SyncMap":       NewSyncMap[testPoint, int](),
+		"LinkedHashMap": NewLinkedHashMap[testPoint, int](),
+		"ExpiringMap":   NewExpiringMap[testPoint, int](0, 0),
+	} {
+		testMapKeys(t, name, m, points)
+	}
+
+	ids := []testUserID{"alice", "bob"}
+	for name, m := range map[string]Map[testUserID, int]{
+		"GoMap":      GoMap[testUserID, int]{},
+		"RWMutexMap": NewRWMutexMap[testUserID, int](),
+		"SyncMap":    NewSyncMap[testUserID, int](),
+		"LRUCache":   NewSafeLRUCache[testUserID, int](4, nil),
+	} {
+		testMapKeys(t, name, m, ids)
+	}
+
+	arrays := [][2]byte{{1, 2}, {2, 1}}
+	testMapKeys[[2]byte](t, "LFUCache", NewLFUCache[[2]byte, int](4, nil), arrays)
+	testMapKeys[[2]byte](t, "ARCCache", NewARCCache[[2]byte, int](4, nil), arrays)
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import "github.com/lynnplus/gotypes/constraints"
+
+var (
+	_ Container       = (*PriorityQueue[int])(nil)
+	_ Enumerable[int] = (*PriorityQueue[int])(nil)
+)
+
+// PriorityItem is a handle to a value in a PriorityQueue, it stays valid until the value leaves the queue.
+type PriorityItem[T any] struct {
+	Value T
+	index int
+	queue *PriorityQueue[T]
+}
+
+// PriorityQueue is a binary heap ordered by a less function, Pop returns the least value first.
+type PriorityQueue[T any] struct {
+	items []*PriorityItem[T]
+	less  func(a, b T) bool
+}
+
+// NewPriorityQueue return a PriorityQueue ordered by less that contains the values, the values are heapified in O(n).
+func NewPriorityQueue[T any](less func(a, b T) bool, values ...T) *PriorityQueue[T] {
+	q := &PriorityQueue[T]{
+		items: make([]*PriorityItem[T], len(values)),
+		less:  less,
+	}
+	for i, v := range values {
+		q.items[i] = &PriorityItem[T]{Value: v, index: i, queue: q}
+	}
+	for i := len(q.items)/2 - 1; i >= 0; i-- {
+		q.down(i)
+	}
+	return q
+}
+
+// NewOrderedPriorityQueue return a PriorityQueue that pops the least value first
+func NewOrderedPriorityQueue[T constraints.Ordered](values ...T) *PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b T) bool {
+		return Compare(a, b) < 0
+	}, values...)
+}
+
+// Push adds the value to the queue and returns its handle
+func (q *PriorityQueue[T]) Push(v T) *PriorityItem[T] {
+	item := &PriorityItem[T]{Value: v, index: len(q.items), queue: q}
+	q.items = append(q.items, item)
+	q.up(item.index)
+	return item
+}
+
+// Pop removes and returns the least value, ok is false if the queue is empty.
+func (q *PriorityQueue[T]) Pop() (v T, ok bool) {
+	if len(q.items) == 0 {
+		return v, false
+	}
+	return q.removeAt(0).Value, true
+}
+
+// Peek returns the least value without removing it.
+func (q *PriorityQueue[T]) Peek() (v T, ok bool) {
+	if len(q.items) == 0 {
+		return v, false
+	}
+	return q.items[0].Value, true
+}
+
+// PeekItem returns the handle of the least value or nil if the queue is empty.
+func (q *PriorityQueue[T]) PeekItem() *PriorityItem[T] {
+	if len(q.items) == 0 {
+		return nil
+	}
+	return q.items[0]
+}
+
+// Update changes the value of the item and restores the heap order, such as for a decrease-key.
+// It reports whether the item belongs to the queue.
+func (q *PriorityQueue[T]) Update(item *PriorityItem[T], v T) bool {
+	if !q.owns(item) {
+		return false
+	}
+	item.Value = v
+	q.fix(item.index)
+	return true
+}
+
+// Fix restores the heap order after the Value of the item was changed in place.
+// It reports whether the item belongs to the queue.
+func (q *PriorityQueue[T]) Fix(item *PriorityItem[T]) bool {
+	if !q.owns(item) {
+		return false
+	}
+	q.fix(item.index)
+	return true
+}
+
+// Remove removes the item from the queue and reports whether it belonged to the queue.
+func (q *PriorityQueue[T]) Remove(item *PriorityItem[T]) bool {
+	if !q.owns(item) {
+		return false
+	}
+	q.removeAt(item.index)
+	return true
+}
+
+// Contains reports whether the item is still in the queue
+func (q *PriorityQueue[T]) Contains(item *PriorityItem[T]) bool {
+	return q.owns(item)
+}
+
+// EachValue calls f for each value in heap order, which is not sorted.
+func (q *PriorityQueue[T]) EachValue(f func(value T)) {
+	for _, item := range q.items {
+		f(item.Value)
+	}
+}
+
+// Values returns all values in heap order, which is not sorted.
+func (q *PriorityQueue[T]) Values() []T {
+	r := make([]T, len(q.items))
+	for i, item := range q.items {
+		r[i] = item.Value
+	}
+	return r
+}
+
+func (q *PriorityQueue[T]) Empty() bool {
+	return len(q.items) == 0
+}
+
+func (q *PriorityQueue[T]) Size() int {
+	return len(q.items)
+}
+
+func (q *PriorityQueue[T]) RemoveAll() {
+	for _, item := range q.items {
+		item.queue, item.index = nil, -1
+	}
+	q.items = nil
+}
+
+func (q *PriorityQueue[T]) owns(item *PriorityItem[T]) bool {
+	return item != nil && item.queue == q
+}
+
+func (q *PriorityQueue[T]) removeAt(i int) *PriorityItem[T] {
+	item := q.items[i]
+	last := len(q.items) - 1
+	if i != last {
+		q.swap(i, last)
+	}
+	q.items[last] = nil
+	q.items = q.items[:last]
+	if i != last {
+		q.fix(i)
+	}
+	item.queue, item.index = nil, -1
+	return item
+}
+
+func (q *PriorityQueue[T]) fix(i int) {
+	if !q.down(i) {
+		q.up(i)
+	}
+}
+
+func (q *PriorityQueue[T]) swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index = i
+	q.items[j].index = j
+}
+
+func (q *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(q.items[i].Value, q.items[parent].Value) {
+			break
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the item at i towards the leaves and reports whether it moved.
+func (q *PriorityQueue[T]) down(i int) bool {
+	start, n := i, len(q.items)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && q.less(q.items[right].Value, q.items[child].Value) {
+			child = right
+		}
+		if !q.less(q.items[child].Value, q.items[i].Value) {
+			break
+		}
+		q.swap(i, child)
+		i = child
+	}
+	return i > start
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	values := rnd.Perm(100)
+	q := NewOrderedPriorityQueue(values[:50]...)
+	items := map[int]*PriorityItem[int]{}
+	for _, v := range values[50:] {
+		items[v] = q.Push(v)
+	}
+
+	// decrease-key and removal through handles
+	for v, item := range items {
+		switch v % 3 {
+		case 0:
+			q.Update(item, v-1000)
+		case 1:
+			q.Remove(item)
+		}
+	}
+	var want []int
+	for _, v := range values[:50] {
+		want = append(want, v)
+	}
+	for v := range items {
+		switch v % 3 {
+		case 0:
+			want = append(want, v-1000)
+		case 2:
+			want = append(want, v)
+		}
+	}
+	sort.Ints(want)
+
+	if q.Size() != len(want) {
+		t.Fatalf("size = %d, want %d", q.Size(), len(want))
+	}
+	for i, w := range want {
+		if v, ok := q.Pop(); !ok || v != w {
+			t.Fatalf("Pop() #%d = %d, want %d", i, v, w)
+		}
+	}
+	for _, item := range items {
+		if q.Contains(item) || q.Update(item, 0) {
+			t.Fatalf("handle still belongs to the queue after it was popped")
+		}
+	}
+}
@@ -0,0 +1,426 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"sort"
+	"strings"
+)
+
+var (
+	_ Container                = (*RadixTree[int])(nil)
+	_ Enumerable2[string, int] = (*RadixTree[int])(nil)
+)
+
+type radixNode[V any] struct {
+	// prefix is the label of the edge from the parent, it is only empty for the root.
+	prefix   string
+	children []*radixNode[V]
+	value    V
+	hasValue bool
+}
+
+func (n *radixNode[V]) search(b byte) int {
+	return sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= b
+	})
+}
+
+func (n *radixNode[V]) child(b byte) *radixNode[V] {
+	i := n.search(b)
+	if i < len(n.children) && n.children[i].prefix[0] == b {
+		return n.children[i]
+	}
+	return nil
+}
+
+func (n *radixNode[V]) setChild(c *radixNode[V]) {
+	i := n.search(c.prefix[0])
+	if i < len(n.children) && n.children[i].prefix[0] == c.prefix[0] {
+		n.children[i] = c
+		return
+	}
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = c
+}
+
+func (n *radixNode[V]) removeChild(b byte) {
+	i := n.search(b)
+	if i < len(n.children) && n.children[i].prefix[0] == b {
+		copy(n.children[i:], n.children[i+1:])
+		n.children[len(n.children)-1] = nil
+		n.children = n.children[:len(n.children)-1]
+	}
+}
+
+// mergeChild merges the only child of n into n, it is used to keep single-child chains compressed.
+func (n *radixNode[V]) mergeChild() {
+	c := n.children[0]
+	n.prefix += c.prefix
+	n.children = c.children
+	n.value = c.value
+	n.hasValue = c.hasValue
+}
+
+func (n *radixNode[V]) count() int {
+	count := 0
+	if n.hasValue {
+		count++
+	}
+	for _, c := range n.children {
+		count += c.count()
+	}
+	return count
+}
+
+// RadixTree is a path-compressed prefix tree keyed by bytes,
+// chains of nodes that have a single child and no value are merged into one edge.
+// Traversal visits keys in lexicographic order.
+type RadixTree[V any] struct {
+	root *radixNode[V]
+	size int
+}
+
+// NewRadixTree return an empty RadixTree
+func NewRadixTree[V any]() *RadixTree[V] {
+	return &RadixTree[V]{root: &radixNode[V]{}}
+}
+
+// Insert stores the value for the key, replacing any existing value.
+func (t *RadixTree[V]) Insert(key string, value V) {
+	if t.root == nil {
+		t.root = &radixNode[V]{}
+	}
+	node := t.root
+	for len(key) > 0 {
+		c := node.child(key[0])
+		if c == nil {
+			node.setChild(&radixNode[V]{prefix: key, value: value, hasValue: true})
+			t.size++
+			return
+		}
+		common := commonPrefixLength(key, c.prefix)
+		if common < len(c.prefix) {
+			mid := &radixNode[V]{prefix: c.prefix[:common], children: []*radixNode[V]{c}}
+			node.setChild(mid)
+			c.prefix = c.prefix[common:]
+			c = mid
+		}
+		key = key[common:]
+		node = c
+	}
+	if !node.hasValue {
+		t.size++
+	}
+	node.value = value
+	node.hasValue = true
+}
+
+// InsertBytes is like Insert but takes the key as a byte slice.
+func (t *RadixTree[V]) InsertBytes(key []byte, value V) {
+	t.Insert(string(key), value)
+}
+
+// Get returns the value stored for the key and whether it was found.
+func (t *RadixTree[V]) Get(key string) (V, bool) {
+	node := t.root
+	for node != nil && len(key) > 0 {
+		c := node.child(key[0])
+		if c == nil || !strings.HasPrefix(key, c.prefix) {
+			return *new(V), false
+		}
+		key = key[len(c.prefix):]
+		node = c
+	}
+	if node == nil || !node.hasValue {
+		return *new(V), false
+	}
+	return node.value, true
+}
+
+// GetBytes is like Get but takes the key as a byte slice, the lookup does not allocate.
+func (t *RadixTree[V]) GetBytes(key []byte) (V, bool) {
+	node := t.root
+	for node != nil && len(key) > 0 {
+		c := node.child(key[0])
+		if c == nil || len(key) < len(c.prefix) || string(key[:len(c.prefix)]) != c.prefix {
+			return *new(V), false
+		}
+		key = key[len(c.prefix):]
+		node = c
+	}
+	if node == nil || !node.hasValue {
+		return *new(V), false
+	}
+	return node.value, true
+}
+
+// Delete removes the key from the tree and reports whether it was present.
+func (t *RadixTree[V]) Delete(key string) bool {
+	if t.root == nil {
+		return false
+	}
+	var parent *radixNode[V]
+	node := t.root
+	for len(key) > 0 {
+		c := node.child(key[0])
+		if c == nil || !strings.HasPrefix(key, c.prefix) {
+			return false
+		}
+		key = key[len(c.prefix):]
+		parent, node = node, c
+	}
+	if !node.hasValue {
+		return false
+	}
+	node.value = *new(V)
+	node.hasValue = false
+	t.size--
+
+	if parent == nil {
+		return true
+	}
+	switch len(node.children) {
+	case 0:
+		parent.removeChild(node.prefix[0])
+		if parent != t.root && !parent.hasValue && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	case 1:
+		node.mergeChild()
+	}
+	return true
+}
+
+// DeleteBytes is like Delete but takes the key as a byte slice.
+func (t *RadixTree[V]) DeleteBytes(key []byte) bool {
+	return t.Delete(string(key))
+}
+
+// DeletePrefix removes all keys beginning with prefix and returns the number of removed keys.
+func (t *RadixTree[V]) DeletePrefix(prefix string) int {
+	if t.root == nil {
+		return 0
+	}
+	if prefix == "" {
+		count := t.size
+		t.RemoveAll()
+		return count
+	}
+	parent := t.root
+	for {
+		c := parent.child(prefix[0])
+		if c == nil {
+			return 0
+		}
+		if strings.HasPrefix(c.prefix, prefix) {
+			count := c.count()
+			parent.removeChild(c.prefix[0])
+			if parent != t.root && !parent.hasValue && len(parent.children) == 1 {
+				parent.mergeChild()
+			}
+			t.size -= count
+			return count
+		}
+		if !strings.HasPrefix(prefix, c.prefix) {
+			return 0
+		}
+		prefix = prefix[len(c.prefix):]
+		parent = c
+	}
+}
+
+// HasPrefix reports whether any key in the tree begins with prefix.
+func (t *RadixTree[V]) HasPrefix(prefix string) bool {
+	node, _ := t.findPrefix(prefix)
+	return node != nil && (node.hasValue || len(node.children) > 0)
+}
+
+// LongestPrefixMatch returns the longest key in the tree that is a prefix of s.
+func (t *RadixTree[V]) LongestPrefixMatch(s string) (key string, value V, ok bool) {
+	node := t.root
+	if node == nil {
+		return "", value, false
+	}
+	if node.hasValue {
+		value, ok = node.value, true
+	}
+	matched, consumed := 0, 0
+	for consumed < len(s) {
+		c := node.child(s[consumed])
+		if c == nil || !strings.HasPrefix(s[consumed:], c.prefix) {
+			break
+		}
+		consumed += len(c.prefix)
+		node = c
+		if node.hasValue {
+			matched, value, ok = consumed, node.value, true
+		}
+	}
+	if !ok {
+		return "", value, false
+	}
+	return s[:matched], value, true
+}
+
+// WalkPrefix calls f in lexicographic order for each key beginning with prefix,
+// the walk stops if f returns false.
+func (t *RadixTree[V]) WalkPrefix(prefix string, f func(key string, value V) bool) {
+	node, path := t.findPrefix(prefix)
+	if node == nil {
+		return
+	}
+	t.walk(node, []byte(path), "", "", f)
+}
+
+// RangeFrom calls f in lexicographic order for each key in the half-open interval [lo, hi),
+// the walk stops if f returns false.
+func (t *RadixTree[V]) RangeFrom(lo, hi string, f func(key string, value V) bool) {
+	if t.root == nil || lo >= hi {
+		return
+	}
+	t.walk(t.root, nil, lo, hi, f)
+}
+
+func (t *RadixTree[V]) Range(f func(key string, value V) bool) {
+	if t.root == nil {
+		return
+	}
+	t.walk(t.root, nil, "", "", f)
+}
+
+func (t *RadixTree[V]) Each(f func(key string, value V)) {
+	t.Range(func(k string, v V) bool {
+		f(k, v)
+		return true
+	})
+}
+
+func (t *RadixTree[V]) Every(f func(key string, value V) bool) bool {
+	ok := true
+	t.Range(func(k string, v V) bool {
+		ok = f(k, v)
+		return ok
+	})
+	return ok
+}
+
+func (t *RadixTree[V]) Some(f func(key string, value V) bool) bool {
+	ok := false
+	t.Range(func(k string, v V) bool {
+		ok = f(k, v)
+		return !ok
+	})
+	return ok
+}
+
+// Keys returns all keys in lexicographic order
+func (t *RadixTree[V]) Keys() []string {
+	keys := make([]string, 0, t.size)
+	t.Each(func(k string, _ V) {
+		keys = append(keys, k)
+	})
+	return keys
+}
+
+// Values returns all values in the lexicographic order of their keys
+func (t *RadixTree[V]) Values() []V {
+	values := make([]V, 0, t.size)
+	t.Each(func(_ string, v V) {
+		values = append(values, v)
+	})
+	return values
+}
+
+func (t *RadixTree[V]) Empty() bool {
+	return t.size == 0
+}
+
+func (t *RadixTree[V]) Size() int {
+	return t.size
+}
+
+func (t *RadixTree[V]) RemoveAll() {
+	t.root = &radixNode[V]{}
+	t.size = 0
+}
+
+// findPrefix returns the highest node whose keys all begin with prefix, and the full key of that node.
+func (t *RadixTree[V]) findPrefix(prefix string) (*radixNode[V], string) {
+	node := t.root
+	if node == nil {
+		return nil, ""
+	}
+	consumed := 0
+	for consumed < len(prefix) {
+		rest := prefix[consumed:]
+		c := node.child(rest[0])
+		if c == nil {
+			return nil, ""
+		}
+		if strings.HasPrefix(c.prefix, rest) {
+			return c, prefix[:consumed] + c.prefix
+		}
+		if !strings.HasPrefix(rest, c.prefix) {
+			return nil, ""
+		}
+		consumed += len(c.prefix)
+		node = c
+	}
+	return node, prefix
+}
+
+// walk visits node and its descendants in preorder, keys outside [lo, hi) are skipped if hi is not empty.
+// It returns false if the walk should stop.
+func (t *RadixTree[V]) walk(node *radixNode[V], path []byte, lo, hi string, f func(key string, value V) bool) bool {
+	bounded := hi != ""
+	if bounded {
+		key := string(path)
+		if key >= hi {
+			return false
+		}
+		// every key in this subtree begins with path, skip it if all of them sort before lo
+		if key < lo && !strings.HasPrefix(lo, key) {
+			return true
+		}
+		if node.hasValue && key >= lo && !f(key, node.value) {
+			return false
+		}
+	} else if node.hasValue && !f(string(path), node.value) {
+		return false
+	}
+	for _, c := range node.children {
+		if !t.walk(c, append(path, c.prefix...), lo, hi, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func commonPrefixLength(a, b string) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
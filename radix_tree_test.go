@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRadixTree(t *testing.T) {
+	tree := NewRadixTree[int]()
+	expected := map[string]int{}
+	rnd := rand.New(rand.NewSource(1))
+	randomKey := func() string {
+		b := make([]byte, rnd.Intn(6))
+		for i := range b {
+			b[i] = "abc/"[rnd.Intn(4)]
+		}
+		return string(b)
+	}
+
+	for i := 0; i < 5000; i++ {
+		key := randomKey()
+		switch rnd.Intn(3) {
+		case 0, 1:
+			tree.Insert(key, i)
+			expected[key] = i
+		case 2:
+			_, ok := expected[key]
+			if tree.Delete(key) != ok {
+				t.Fatalf("Delete(%q) mismatch", key)
+			}
+			delete(expected, key)
+		}
+		if tree.Size() != len(expected) {
+			t.Fatalf("size = %d, want %d", tree.Size(), len(expected))
+		}
+	}
+
+	keys := make([]string, 0, len(expected))
+	for k, v := range expected {
+		keys = append(keys, k)
+		if got, ok := tree.GetBytes([]byte(k)); !ok || got != v {
+			t.Fatalf("GetBytes(%q) = %v, %v, want %v", k, got, ok, v)
+		}
+	}
+	sort.Strings(keys)
+	if !reflect.DeepEqual(tree.Keys(), keys) {
+		t.Fatalf("Keys() = %v, want %v", tree.Keys(), keys)
+	}
+
+	var ranged, want []string
+	tree.RangeFrom("ab", "b/", func(key string, _ int) bool {
+		ranged = append(ranged, key)
+		return true
+	})
+	for _, k := range keys {
+		if k >= "ab" && k < "b/" {
+			want = append(want, k)
+		}
+	}
+	if !reflect.DeepEqual(ranged, want) {
+		t.Fatalf("RangeFrom(ab, b/) = %v, want %v", ranged, want)
+	}
+
+	removed := 0
+	for _, k := range keys {
+		if strings.HasPrefix(k, "a/") {
+			removed++
+		}
+	}
+	if n := tree.DeletePrefix("a/"); n != removed {
+		t.Fatalf("DeletePrefix(a/) = %d, want %d", n, removed)
+	}
+	if tree.HasPrefix("a/") || tree.Size() != len(keys)-removed {
+		t.Fatalf("keys with prefix a/ remain after DeletePrefix")
+	}
+
+	tree.RemoveAll()
+	tree.Insert("/api/", 1)
+	tree.Insert("/api/users", 2)
+	if k, v, ok := tree.LongestPrefixMatch("/api/users/42"); !ok || k != "/api/users" || v != 2 {
+		t.Fatalf("LongestPrefixMatch = %q, %v, %v", k, v, ok)
+	}
+	if k, _, ok := tree.LongestPrefixMatch("/api/orders"); !ok || k != "/api/" {
+		t.Fatalf("LongestPrefixMatch = %q, %v", k, ok)
+	}
+}
@@ -0,0 +1,899 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"sort"
+)
+
+var (
+	_ Container          = (*RoaringBitmap)(nil)
+	_ Enumerable[uint32] = (*RoaringBitmap)(nil)
+)
+
+const (
+	// roaringArrayMax is the largest cardinality stored in an array container
+	roaringArrayMax    = 4096
+	roaringBitmapWords = 1 << 16 / 64
+
+	roaringSerialCookieNoRun = 12346
+	roaringSerialCookie      = 12347
+	roaringNoOffsetThreshold = 4
+)
+
+var errInvalidRoaring = errors.New("gotypes: invalid roaring bitmap encoding")
+
+// roaringContainer holds the low 16 bits of the values that share the same high 16 bits.
+// Methods that modify a container return the container that replaces it,
+// which may be of another kind or nil once it becomes empty.
+type roaringContainer interface {
+	cardinality() int
+	contains(x uint16) bool
+	add(x uint16) roaringContainer
+	remove(x uint16) roaringContainer
+	// rank returns the number of values less than or equal to x
+	rank(x uint16) int
+	// selectAt returns the value at index i in ascending order, i must be less than the cardinality
+	selectAt(i int) uint16
+	// iterate calls f for each value in ascending order, it returns false if f stopped the iteration
+	iterate(f func(x uint16) bool) bool
+	clone() roaringContainer
+	// asBitmap returns the container as a bitmap container that must not be modified
+	asBitmap() *roaringBitmapContainer
+	// numRuns returns the number of runs of consecutive values
+	numRuns() int
+}
+
+type roaringArrayContainer struct {
+	values []uint16
+}
+
+func (c *roaringArrayContainer) search(x uint16) int {
+	return sort.Search(len(c.values), func(i int) bool {
+		return c.values[i] >= x
+	})
+}
+
+func (c *roaringArrayContainer) cardinality() int {
+	return len(c.values)
+}
+
+func (c *roaringArrayContainer) contains(x uint16) bool {
+	i := c.search(x)
+	return i < len(c.values) && c.values[i] == x
+}
+
+func (c *roaringArrayContainer) add(x uint16) roaringContainer {
+	i := c.search(x)
+	if i < len(c.values) && c.values[i] == x {
+		return c
+	}
+	if len(c.values) >= roaringArrayMax {
+		return c.asBitmap().add(x)
+	}
+	c.values = insertSlice(c.values, i, x)
+	return c
+}
+
+func (c *roaringArrayContainer) remove(x uint16) roaringContainer {
+	i := c.search(x)
+	if i < len(c.values) && c.values[i] == x {
+		c.values = removeSlice(c.values, i, i+1)
+	}
+	if len(c.values) == 0 {
+		return nil
+	}
+	return c
+}
+
+func (c *roaringArrayContainer) rank(x uint16) int {
+	return sort.Search(len(c.values), func(i int) bool {
+		return c.values[i] > x
+	})
+}
+
+func (c *roaringArrayContainer) selectAt(i int) uint16 {
+	return c.values[i]
+}
+
+func (c *roaringArrayContainer) iterate(f func(x uint16) bool) bool {
+	for _, v := range c.values {
+		if !f(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *roaringArrayContainer) clone() roaringContainer {
+	values := make([]uint16, len(c.values))
+	copy(values, c.values)
+	return &roaringArrayContainer{values: values}
+}
+
+func (c *roaringArrayContainer) asBitmap() *roaringBitmapContainer {
+	b := newRoaringBitmapContainer()
+	for _, v := range c.values {
+		b.words[v/64] |= 1 << (v % 64)
+	}
+	b.card = len(c.values)
+	return b
+}
+
+func (c *roaringArrayContainer) numRuns() int {
+	runs := 0
+	for i, v := range c.values {
+		if i == 0 || v != c.values[i-1]+1 {
+			runs++
+		}
+	}
+	return runs
+}
+
+type roaringBitmapContainer struct {
+	words []uint64
+	card  int
+}
+
+func newRoaringBitmapContainer() *roaringBitmapContainer {
+	return &roaringBitmapContainer{words: make([]uint64, roaringBitmapWords)}
+}
+
+func (c *roaringBitmapContainer) cardinality() int {
+	return c.card
+}
+
+func (c *roaringBitmapContainer) contains(x uint16) bool {
+	return c.words[x/64]&(1<<(x%64)) != 0
+}
+
+func (c *roaringBitmapContainer) add(x uint16) roaringContainer {
+	if !c.contains(x) {
+		c.words[x/64] |= 1 << (x % 64)
+		c.card++
+	}
+	return c
+}
+
+func (c *roaringBitmapContainer) remove(x uint16) roaringContainer {
+	if c.contains(x) {
+		c.words[x/64] &^= 1 << (x % 64)
+		c.card--
+	}
+	return c.normalize()
+}
+
+func (c *roaringBitmapContainer) rank(x uint16) int {
+	r := 0
+	for _, w := range c.words[:x/64] {
+		r += bits.OnesCount64(w)
+	}
+	// the shift overflows to zero for bit 63, and the mask then covers the whole word
+	return r + bits.OnesCount64(c.words[x/64]&(2<<(x%64)-1))
+}
+
+func (c *roaringBitmapContainer) selectAt(i int) uint16 {
+	for w, word := range c.words {
+		n := bits.OnesCount64(word)
+		if i >= n {
+			i -= n
+			continue
+		}
+		for ; i > 0; i-- {
+			word &= word - 1
+		}
+		return uint16(w*64 + bits.TrailingZeros64(word))
+	}
+	return 0
+}
+
+func (c *roaringBitmapContainer) iterate(f func(x uint16) bool) bool {
+	for w, word := range c.words {
+		for word != 0 {
+			if !f(uint16(w*64 + bits.TrailingZeros64(word))) {
+				return false
+			}
+			word &= word - 1
+		}
+	}
+	return true
+}
+
+func (c *roaringBitmapContainer) clone() roaringContainer {
+	words := make([]uint64, len(c.words))
+	copy(words, c.words)
+	return &roaringBitmapContainer{words: words, card: c.card}
+}
+
+func (c *roaringBitmapContainer) asBitmap() *roaringBitmapContainer {
+	return c
+}
+
+func (c *roaringBitmapContainer) numRuns() int {
+	runs := 0
+	for i, w := range c.words {
+		// a run starts at every set bit whose preceding bit is clear
+		prev := w << 1
+		if i > 0 {
+			prev |= c.words[i-1] >> 63
+		}
+		runs += bits.OnesCount64(w &^ prev)
+	}
+	return runs
+}
+
+// normalize converts the container to an array container if its cardinality is small enough
+func (c *roaringBitmapContainer) normalize() roaringContainer {
+	if c.card == 0 {
+		return nil
+	}
+	if c.card > roaringArrayMax {
+		return c
+	}
+	values := make([]uint16, 0, c.card)
+	c.iterate(func(x uint16) bool {
+		values = append(values, x)
+		return true
+	})
+	return &roaringArrayContainer{values: values}
+}
+
+// roaringRun is a run of consecutive values from start to start+length inclusive
+type roaringRun struct {
+	start  uint16
+	length uint16
+}
+
+type roaringRunContainer struct {
+	runs []roaringRun
+}
+
+func (c *roaringRunContainer) cardinality() int {
+	card := 0
+	for _, r := range c.runs {
+		card += int(r.length) + 1
+	}
+	return card
+}
+
+// search returns the index of the last run that starts at or before x, or -1.
+func (c *roaringRunContainer) search(x uint16) int {
+	return sort.Search(len(c.runs), func(i int) bool {
+		return c.runs[i].start > x
+	}) - 1
+}
+
+func (c *roaringRunContainer) contains(x uint16) bool {
+	i := c.search(x)
+	return i >= 0 && int(x) <= int(c.runs[i].start)+int(c.runs[i].length)
+}
+
+// add and remove convert the container first, RunOptimize compresses it again.
+func (c *roaringRunContainer) add(x uint16) roaringContainer {
+	if c.contains(x) {
+		return c
+	}
+	return c.expand().add(x)
+}
+
+func (c *roaringRunContainer) remove(x uint16) roaringContainer {
+	if !c.contains(x) {
+		return c
+	}
+	return c.expand().remove(x)
+}
+
+func (c *roaringRunContainer) rank(x uint16) int {
+	r := 0
+	for _, run := range c.runs {
+		if run.start > x {
+			break
+		}
+		end := int(run.start) + int(run.length)
+		if int(x) < end {
+			end = int(x)
+		}
+		r += end - int(run.start) + 1
+	}
+	return r
+}
+
+func (c *roaringRunContainer) selectAt(i int) uint16 {
+	for _, run := range c.runs {
+		if i <= int(run.length) {
+			return run.start + uint16(i)
+		}
+		i -= int(run.length) + 1
+	}
+	return 0
+}
+
+func (c *roaringRunContainer) iterate(f func(x uint16) bool) bool {
+	for _, run := range c.runs {
+		for v := int(run.start); v <= int(run.start)+int(run.length); v++ {
+			if !f(uint16(v)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c *roaringRunContainer) clone() roaringContainer {
+	runs := make([]roaringRun, len(c.runs))
+	copy(runs, c.runs)
+	return &roaringRunContainer{runs: runs}
+}
+
+func (c *roaringRunContainer) asBitmap() *roaringBitmapContainer {
+	b := newRoaringBitmapContainer()
+	c.iterate(func(x uint16) bool {
+		b.words[x/64] |= 1 << (x % 64)
+		return true
+	})
+	b.card = c.cardinality()
+	return b
+}
+
+func (c *roaringRunContainer) numRuns() int {
+	return len(c.runs)
+}
+
+// expand converts the container to an array or bitmap container
+func (c *roaringRunContainer) expand() roaringContainer {
+	if c.cardinality() > roaringArrayMax {
+		return c.asBitmap()
+	}
+	values := make([]uint16, 0, c.cardinality())
+	c.iterate(func(x uint16) bool {
+		values = append(values, x)
+		return true
+	})
+	return &roaringArrayContainer{values: values}
+}
+
+func newRoaringRunContainer(c roaringContainer) *roaringRunContainer {
+	runs := make([]roaringRun, 0, c.numRuns())
+	c.iterate(func(x uint16) bool {
+		if n := len(runs); n > 0 && int(runs[n-1].start)+int(runs[n-1].length)+1 == int(x) {
+			runs[n-1].length++
+		} else {
+			runs = append(runs, roaringRun{start: x})
+		}
+		return true
+	})
+	return &roaringRunContainer{runs: runs}
+}
+
+// mergeRoaringArrays merges two sorted arrays and keeps the values that are only in a, in both, or only in b.
+func mergeRoaringArrays(a, b []uint16, onlyA, both, onlyB bool) roaringContainer {
+	values := make([]uint16, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			if onlyA {
+				values = append(values, a[i])
+			}
+			i++
+		case a[i] > b[j]:
+			if onlyB {
+				values = append(values, b[j])
+			}
+			j++
+		default:
+			if both {
+				values = append(values, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	if onlyA {
+		values = append(values, a[i:]...)
+	}
+	if onlyB {
+		values = append(values, b[j:]...)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	if len(values) > roaringArrayMax {
+		return (&roaringArrayContainer{values: values}).asBitmap()
+	}
+	return &roaringArrayContainer{values: values}
+}
+
+func combineRoaringBitmaps(a, b *roaringBitmapContainer, op func(x, y uint64) uint64) roaringContainer {
+	r := newRoaringBitmapContainer()
+	for i := range r.words {
+		r.words[i] = op(a.words[i], b.words[i])
+		r.card += bits.OnesCount64(r.words[i])
+	}
+	return r.normalize()
+}
+
+// filterRoaringArray keeps the values of a for which keep returns true
+func filterRoaringArray(a *roaringArrayContainer, keep func(x uint16) bool) roaringContainer {
+	values := make([]uint16, 0, len(a.values))
+	for _, v := range a.values {
+		if keep(v) {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return &roaringArrayContainer{values: values}
+}
+
+func roaringAnd(a, b roaringContainer) roaringContainer {
+	if x, ok := a.(*roaringArrayContainer); ok {
+		return filterRoaringArray(x, b.contains)
+	}
+	if y, ok := b.(*roaringArrayContainer); ok {
+		return filterRoaringArray(y, a.contains)
+	}
+	return combineRoaringBitmaps(a.asBitmap(), b.asBitmap(), func(x, y uint64) uint64 { return x & y })
+}
+
+func roaringOr(a, b roaringContainer) roaringContainer {
+	x, ok1 := a.(*roaringArrayContainer)
+	y, ok2 := b.(*roaringArrayContainer)
+	if ok1 && ok2 {
+		return mergeRoaringArrays(x.values, y.values, true, true, true)
+	}
+	return combineRoaringBitmaps(a.asBitmap(), b.asBitmap(), func(x, y uint64) uint64 { return x | y })
+}
+
+func roaringAndNot(a, b roaringContainer) roaringContainer {
+	if x, ok := a.(*roaringArrayContainer); ok {
+		return filterRoaringArray(x, func(v uint16) bool { return !b.contains(v) })
+	}
+	return combineRoaringBitmaps(a.asBitmap(), b.asBitmap(), func(x, y uint64) uint64 { return x &^ y })
+}
+
+func roaringXor(a, b roaringContainer) roaringContainer {
+	x, ok1 := a.(*roaringArrayContainer)
+	y, ok2 := b.(*roaringArrayContainer)
+	if ok1 && ok2 {
+		return mergeRoaringArrays(x.values, y.values, true, false, true)
+	}
+	return combineRoaringBitmaps(a.asBitmap(), b.asBitmap(), func(x, y uint64) uint64 { return x ^ y })
+}
+
+// RoaringBitmap is a compressed set of uint32 values.
+// Values are partitioned by their high 16 bits into containers that are stored as
+// a sorted array, a bitmap or a list of runs, whichever is appropriate for their density.
+// The binary encoding follows the portable Roaring format specification.
+type RoaringBitmap struct {
+	keys       []uint16
+	containers []roaringContainer
+}
+
+// NewRoaringBitmap return a RoaringBitmap that contains the values
+func NewRoaringBitmap(values ...uint32) *RoaringBitmap {
+	b := &RoaringBitmap{}
+	b.Add(values...)
+	return b
+}
+
+func (b *RoaringBitmap) search(key uint16) (int, bool) {
+	i := sort.Search(len(b.keys), func(i int) bool {
+		return b.keys[i] >= key
+	})
+	return i, i < len(b.keys) && b.keys[i] == key
+}
+
+func (b *RoaringBitmap) Add(values ...uint32) {
+	for _, v := range values {
+		key, low := uint16(v>>16), uint16(v)
+		i, ok := b.search(key)
+		if ok {
+			b.containers[i] = b.containers[i].add(low)
+			continue
+		}
+		b.keys = insertSlice(b.keys, i, key)
+		b.containers = insertSlice[roaringContainer](b.containers, i, &roaringArrayContainer{values: []uint16{low}})
+	}
+}
+
+func (b *RoaringBitmap) Remove(values ...uint32) {
+	for _, v := range values {
+		i, ok := b.search(uint16(v >> 16))
+		if !ok {
+			continue
+		}
+		if c := b.containers[i].remove(uint16(v)); c != nil {
+			b.containers[i] = c
+		} else {
+			b.keys = removeSlice(b.keys, i, i+1)
+			b.containers = removeSlice(b.containers, i, i+1)
+		}
+	}
+}
+
+func (b *RoaringBitmap) Contains(v uint32) bool {
+	i, ok := b.search(uint16(v >> 16))
+	return ok && b.containers[i].contains(uint16(v))
+}
+
+// Cardinality returns the number of values in the bitmap
+func (b *RoaringBitmap) Cardinality() uint64 {
+	var card uint64
+	for _, c := range b.containers {
+		card += uint64(c.cardinality())
+	}
+	return card
+}
+
+// Rank returns the number of values less than or equal to v
+func (b *RoaringBitmap) Rank(v uint32) uint64 {
+	var r uint64
+	key := uint16(v >> 16)
+	for i, k := range b.keys {
+		if k > key {
+			break
+		}
+		if k < key {
+			r += uint64(b.containers[i].cardinality())
+		} else {
+			r += uint64(b.containers[i].rank(uint16(v)))
+		}
+	}
+	return r
+}
+
+// Select returns the value at index i in ascending order, ok is false if i is not less than the cardinality.
+func (b *RoaringBitmap) Select(i uint64) (uint32, bool) {
+	for k, c := range b.containers {
+		card := uint64(c.cardinality())
+		if i < card {
+			return uint32(b.keys[k])<<16 | uint32(c.selectAt(int(i))), true
+		}
+		i -= card
+	}
+	return 0, false
+}
+
+// Min returns the least value, ok is false if the bitmap is empty.
+func (b *RoaringBitmap) Min() (uint32, bool) {
+	return b.Select(0)
+}
+
+// Max returns the greatest value, ok is false if the bitmap is empty.
+func (b *RoaringBitmap) Max() (uint32, bool) {
+	n := len(b.containers)
+	if n == 0 {
+		return 0, false
+	}
+	c := b.containers[n-1]
+	return uint32(b.keys[n-1])<<16 | uint32(c.selectAt(c.cardinality()-1)), true
+}
+
+// Range calls f for each value in ascending order, f must not modify the bitmap.
+func (b *RoaringBitmap) Range(f func(v uint32) bool) {
+	for i, c := range b.containers {
+		high := uint32(b.keys[i]) << 16
+		if !c.iterate(func(x uint16) bool {
+			return f(high | uint32(x))
+		}) {
+			return
+		}
+	}
+}
+
+func (b *RoaringBitmap) EachValue(f func(value uint32)) {
+	b.Range(func(v uint32) bool {
+		f(v)
+		return true
+	})
+}
+
+// Values returns all values in ascending order
+func (b *RoaringBitmap) Values() []uint32 {
+	r := make([]uint32, 0, b.Cardinality())
+	b.EachValue(func(v uint32) {
+		r = append(r, v)
+	})
+	return r
+}
+
+func (b *RoaringBitmap) Clone() *RoaringBitmap {
+	r := &RoaringBitmap{
+		keys:       make([]uint16, len(b.keys)),
+		containers: make([]roaringContainer, len(b.containers)),
+	}
+	copy(r.keys, b.keys)
+	for i, c := range b.containers {
+		r.containers[i] = c.clone()
+	}
+	return r
+}
+
+// Equal reports whether both bitmaps contain the same values
+func (b *RoaringBitmap) Equal(other *RoaringBitmap) bool {
+	if len(b.keys) != len(other.keys) {
+		return false
+	}
+	for i, k := range b.keys {
+		if k != other.keys[i] || b.containers[i].cardinality() != other.containers[i].cardinality() {
+			return false
+		}
+		if roaringXor(b.containers[i], other.containers[i]) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *RoaringBitmap) Empty() bool {
+	return len(b.containers) == 0
+}
+
+// Size returns the number of values, it is the same as Cardinality
+func (b *RoaringBitmap) Size() int {
+	return int(b.Cardinality())
+}
+
+func (b *RoaringBitmap) RemoveAll() {
+	b.keys = nil
+	b.containers = nil
+}
+
+// Or returns a new bitmap with the values that are in b or other
+func (b *RoaringBitmap) Or(other *RoaringBitmap) *RoaringBitmap {
+	return b.combine(other, true, true, roaringOr)
+}
+
+// And returns a new bitmap with the values that are in both b and other
+func (b *RoaringBitmap) And(other *RoaringBitmap) *RoaringBitmap {
+	return b.combine(other, false, false, roaringAnd)
+}
+
+// AndNot returns a new bitmap with the values that are in b but not in other
+func (b *RoaringBitmap) AndNot(other *RoaringBitmap) *RoaringBitmap {
+	return b.combine(other, true, false, roaringAndNot)
+}
+
+// Xor returns a new bitmap with the values that are in exactly one of b and other
+func (b *RoaringBitmap) Xor(other *RoaringBitmap) *RoaringBitmap {
+	return b.combine(other, true, true, roaringXor)
+}
+
+// combine merges the containers of both bitmaps by key, containers whose key is only in b or only in other
+// are copied if keepB or keepOther is set, containers with the same key are combined by op.
+func (b *RoaringBitmap) combine(other *RoaringBitmap, keepB, keepOther bool,
+	op func(a, b roaringContainer) roaringContainer) *RoaringBitmap {
+	r := &RoaringBitmap{}
+	appendContainer := func(key uint16, c roaringContainer) {
+		if c != nil {
+			r.keys = append(r.keys, key)
+			r.containers = append(r.containers, c)
+		}
+	}
+	i, j := 0, 0
+	for i < len(b.keys) && j < len(other.keys) {
+		switch {
+		case b.keys[i] < other.keys[j]:
+			if keepB {
+				appendContainer(b.keys[i], b.containers[i].clone())
+			}
+			i++
+		case b.keys[i] > other.keys[j]:
+			if keepOther {
+				appendContainer(other.keys[j], other.containers[j].clone())
+			}
+			j++
+		default:
+			appendContainer(b.keys[i], op(b.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+	for ; keepB && i < len(b.keys); i++ {
+		appendContainer(b.keys[i], b.containers[i].clone())
+	}
+	for ; keepOther && j < len(other.keys); j++ {
+		appendContainer(other.keys[j], other.containers[j].clone())
+	}
+	return r
+}
+
+// RunOptimize converts each container to the kind with the smallest serialized size,
+// runs of consecutive values are compressed into run containers.
+func (b *RoaringBitmap) RunOptimize() {
+	for i, c := range b.containers {
+		card := c.cardinality()
+		runSize := 2 + 4*c.numRuns()
+		otherSize := 2 * card
+		if card > roaringArrayMax {
+			otherSize = roaringBitmapWords * 8
+		}
+		_, isRun := c.(*roaringRunContainer)
+		if runSize < otherSize && !isRun {
+			b.containers[i] = newRoaringRunContainer(c)
+		} else if runSize >= otherSize && isRun {
+			b.containers[i] = c.(*roaringRunContainer).expand()
+		}
+	}
+}
+
+// MarshalBinary encodes the bitmap in the portable Roaring format
+func (b *RoaringBitmap) MarshalBinary() ([]byte, error) {
+	n := len(b.containers)
+	hasRun := false
+	for _, c := range b.containers {
+		if _, ok := c.(*roaringRunContainer); ok {
+			hasRun = true
+			break
+		}
+	}
+
+	var data []byte
+	if hasRun {
+		data = binary.LittleEndian.AppendUint32(data, roaringSerialCookie|uint32(n-1)<<16)
+		runFlags := make([]byte, (n+7)/8)
+		for i, c := range b.containers {
+			if _, ok := c.(*roaringRunContainer); ok {
+				runFlags[i/8] |= 1 << (i % 8)
+			}
+		}
+		data = append(data, runFlags...)
+	} else {
+		data = binary.LittleEndian.AppendUint32(data, roaringSerialCookieNoRun)
+		data = binary.LittleEndian.AppendUint32(data, uint32(n))
+	}
+	for i, c := range b.containers {
+		data = binary.LittleEndian.AppendUint16(data, b.keys[i])
+		data = binary.LittleEndian.AppendUint16(data, uint16(c.cardinality()-1))
+	}
+	if !hasRun || n >= roaringNoOffsetThreshold {
+		offset := len(data) + 4*n
+		for _, c := range b.containers {
+			data = binary.LittleEndian.AppendUint32(data, uint32(offset))
+			offset += roaringContainerSize(c)
+		}
+	}
+	for _, c := range b.containers {
+		switch c := c.(type) {
+		case *roaringArrayContainer:
+			for _, v := range c.values {
+				data = binary.LittleEndian.AppendUint16(data, v)
+			}
+		case *roaringBitmapContainer:
+			for _, w := range c.words {
+				data = binary.LittleEndian.AppendUint64(data, w)
+			}
+		case *roaringRunContainer:
+			data = binary.LittleEndian.AppendUint16(data, uint16(len(c.runs)))
+			for _, r := range c.runs {
+				data = binary.LittleEndian.AppendUint16(data, r.start)
+				data = binary.LittleEndian.AppendUint16(data, r.length)
+			}
+		}
+	}
+	return data, nil
+}
+
+func roaringContainerSize(c roaringContainer) int {
+	switch c := c.(type) {
+	case *roaringArrayContainer:
+		return 2 * len(c.values)
+	case *roaringRunContainer:
+		return 2 + 4*len(c.runs)
+	}
+	return roaringBitmapWords * 8
+}
+
+// UnmarshalBinary decodes a bitmap in the portable Roaring format and replaces the content of b.
+func (b *RoaringBitmap) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return errInvalidRoaring
+	}
+	cookie := binary.LittleEndian.Uint32(data)
+	pos := 4
+	var n int
+	var runFlags []byte
+	switch {
+	case cookie&0xFFFF == roaringSerialCookie:
+		n = int(cookie>>16) + 1
+		if len(data) < pos+(n+7)/8 {
+			return errInvalidRoaring
+		}
+		runFlags = data[pos : pos+(n+7)/8]
+		pos += (n + 7) / 8
+	case cookie == roaringSerialCookieNoRun:
+		if len(data) < 8 {
+			return errInvalidRoaring
+		}
+		n = int(binary.LittleEndian.Uint32(data[4:]))
+		pos = 8
+	default:
+		return errInvalidRoaring
+	}
+	if n > 1<<16 || len(data) < pos+4*n {
+		return errInvalidRoaring
+	}
+
+	keys := make([]uint16, n)
+	cards := make([]int, n)
+	for i := 0; i < n; i++ {
+		keys[i] = binary.LittleEndian.Uint16(data[pos:])
+		cards[i] = int(binary.LittleEndian.Uint16(data[pos+2:])) + 1
+		pos += 4
+		if i > 0 && keys[i] <= keys[i-1] {
+			return errInvalidRoaring
+		}
+	}
+	if runFlags == nil || n >= roaringNoOffsetThreshold {
+		// the offsets are redundant for a sequential read
+		pos += 4 * n
+	}
+
+	containers := make([]roaringContainer, n)
+	for i := 0; i < n; i++ {
+		isRun := runFlags != nil && runFlags[i/8]&(1<<(i%8)) != 0
+		switch {
+		case isRun:
+			if len(data) < pos+2 {
+				return errInvalidRoaring
+			}
+			runs := make([]roaringRun, binary.LittleEndian.Uint16(data[pos:]))
+			pos += 2
+			if len(data) < pos+4*len(runs) {
+				return errInvalidRoaring
+			}
+			for j := range runs {
+				runs[j].start = binary.LittleEndian.Uint16(data[pos:])
+				runs[j].length = binary.LittleEndian.Uint16(data[pos+2:])
+				pos += 4
+				if int(runs[j].start)+int(runs[j].length) > 0xFFFF {
+					return errInvalidRoaring
+				}
+			}
+			containers[i] = &roaringRunContainer{runs: runs}
+		case cards[i] <= roaringArrayMax:
+			if len(data) < pos+2*cards[i] {
+				return errInvalidRoaring
+			}
+			values := make([]uint16, cards[i])
+			for j := range values {
+				values[j] = binary.LittleEndian.Uint16(data[pos:])
+				pos += 2
+			}
+			containers[i] = &roaringArrayContainer{values: values}
+		default:
+			if len(data) < pos+8*roaringBitmapWords {
+				return errInvalidRoaring
+			}
+			c := newRoaringBitmapContainer()
+			for j := range c.words {
+				c.words[j] = binary.LittleEndian.Uint64(data[pos:])
+				c.card += bits.OnesCount64(c.words[j])
+				pos += 8
+			}
+			containers[i] = c
+		}
+	}
+	b.keys = keys
+	b.containers = containers
+	return nil
+}
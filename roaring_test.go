@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestRoaringBitmap(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a, b := NewRoaringBitmap(), NewRoaringBitmap()
+	ma, mb := NewSet[uint32](), NewSet[uint32]()
+	for i := 0; i < 30000; i++ {
+		// mix sparse values, a dense chunk and long runs
+		var v uint32
+		switch rnd.Intn(3) {
+		case 0:
+			v = rnd.Uint32()
+		case 1:
+			v = 1<<16 + uint32(rnd.Intn(1<<16))
+		case 2:
+			v = 5<<16 + uint32(i)
+		}
+		if rnd.Intn(2) == 0 {
+			a.Add(v)
+			ma.Add(v)
+		} else {
+			b.Add(v)
+			mb.Add(v)
+		}
+	}
+	for i := 0; i < 5000; i++ {
+		v := 1<<16 + uint32(rnd.Intn(1<<16))
+		a.Remove(v)
+		ma.Remove(v)
+	}
+	b.RunOptimize()
+
+	sorted := func(s Set[uint32]) []uint32 {
+		r := s.Values()
+		sort.Slice(r, func(i, j int) bool { return r[i] < r[j] })
+		return r
+	}
+	check := func(name string, got *RoaringBitmap, want Set[uint32]) {
+		if !reflect.DeepEqual(got.Values(), sorted(want)) || got.Size() != want.Size() {
+			t.Fatalf("%s mismatch: %d values, want %d", name, got.Size(), want.Size())
+		}
+	}
+	check("a", a, ma)
+	check("b", b, mb)
+	check("Or", a.Or(b), ma.Union(mb))
+	check("And", a.And(b), ma.Intersect(mb))
+	check("AndNot", a.AndNot(b), ma.Difference(mb))
+	check("Xor", a.Xor(b), ma.SymmetricDifference(mb))
+
+	values := a.Values()
+	for _, i := range []int{0, 1, len(values) / 2, len(values) - 1} {
+		if v, ok := a.Select(uint64(i)); !ok || v != values[i] {
+			t.Fatalf("Select(%d) = %d, %v, want %d", i, v, ok, values[i])
+		}
+		if r := a.Rank(values[i]); r != uint64(i+1) {
+			t.Fatalf("Rank(%d) = %d, want %d", values[i], r, i+1)
+		}
+	}
+
+	for _, bm := range []*RoaringBitmap{a, b} {
+		data, _ := bm.MarshalBinary()
+		decoded := NewRoaringBitmap()
+		if err := decoded.UnmarshalBinary(data); err != nil || !decoded.Equal(bm) {
+			t.Fatalf("binary round trip failed: %v", err)
+		}
+	}
+}
+
+func TestRoaringBitmapFormat(t *testing.T) {
+	// expected encodings follow the Roaring format specification
+	b := NewRoaringBitmap(1, 2, 3)
+	data, _ := b.MarshalBinary()
+	want := []byte{
+		0x3A, 0x30, 0, 0, 1, 0, 0, 0, // cookie without runs, one container
+		0, 0, 2, 0, // key 0, cardinality - 1
+		16, 0, 0, 0, // offset of the container
+		1, 0, 2, 0, 3, 0, // array container
+	}
+	if !bytes.Equal(data, want) {
+		t.Fatalf("array encoding = %v, want %v", data, want)
+	}
+
+	b = NewRoaringBitmap()
+	for v := uint32(1); v <= 100; v++ {
+		b.Add(v)
+	}
+	b.RunOptimize()
+	data, _ = b.MarshalBinary()
+	want = []byte{
+		0x3B, 0x30, 0, 0, // cookie with runs, one container
+		1,           // run flags
+		0, 0, 99, 0, // key 0, cardinality - 1
+		1, 0, 1, 0, 99, 0, // one run from 1 with length 99
+	}
+	if !bytes.Equal(data, want) {
+		t.Fatalf("run encoding = %v, want %v", data, want)
+	}
+}
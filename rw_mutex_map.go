@@ -16,26 +16,32 @@
 
 package gotypes
 
-import (
-	"github.com/lynnplus/gotypes/constraints"
-	"sync"
-)
+import "sync"
 
 var (
-	_ SafeMap[int, int] = (*RWMutexMap[int, int])(nil)
-	_ Enumerable[bool]  = (*RWMutexMap[int, bool])(nil)
+	_ AtomicMap[int, int] = (*RWMutexMap[int, int])(nil)
+	_ Enumerable[bool]    = (*RWMutexMap[int, bool])(nil)
 )
 
-// RWMutexMap implements the SafeMap[K,V] interface
-type RWMutexMap[K constraints.Basic, V any] struct {
-	lock *sync.RWMutex
-	bm   map[K]V
+// RWMutexMap implements the AtomicMap[K,V] interface
+type RWMutexMap[K comparable, V any] struct {
+	lock  *sync.RWMutex
+	bm    map[K]V
+	equal func(a, b V) bool
+}
+
+// NewRWMutexMap return an RWMutexMap that compares values with the == operator,
+// or with reflect.DeepEqual if V may hold values that == cannot compare.
+func NewRWMutexMap[K comparable, V any]() *RWMutexMap[K, V] {
+	return NewRWMutexMapFunc[K, V](defaultEqual[V]())
 }
 
-func NewRWMutexMap[K constraints.Basic, V any]() *RWMutexMap[K, V] {
+// NewRWMutexMapFunc return an RWMutexMap that compares values with the equal function
+func NewRWMutexMapFunc[K comparable, V any](equal func(a, b V) bool) *RWMutexMap[K, V] {
 	return &RWMutexMap[K, V]{
-		lock: new(sync.RWMutex),
-		bm:   make(map[K]V),
+		lock:  new(sync.RWMutex),
+		bm:    make(map[K]V),
+		equal: equal,
 	}
 }
 
@@ -131,13 +137,9 @@ func (m *RWMutexMap[K, V]) DeleteAll() {
 }
 
 func (m *RWMutexMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
-	temp, ok := m.Load(key)
-	if ok {
-		return temp, true
-	}
 	m.lock.Lock()
 	defer m.lock.Unlock()
-	temp, ok = m.bm[key]
+	temp, ok := m.bm[key]
 	if ok {
 		return temp, true
 	}
@@ -162,3 +164,68 @@ func (m *RWMutexMap[K, V]) Data() map[K]V {
 	}
 	return r
 }
+
+// Compute calls f while holding the write lock, f must not access the map.
+func (m *RWMutexMap[K, V]) Compute(key K, f func(old V, loaded bool) (value V, keep bool)) (actual V, ok bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	old, loaded := m.bm[key]
+	actual, ok = f(old, loaded)
+	if !ok {
+		delete(m.bm, key)
+		return *new(V), false
+	}
+	m.bm[key] = actual
+	return actual, true
+}
+
+// ComputeIfAbsent calls factory while holding the write lock, factory must not access the map.
+func (m *RWMutexMap[K, V]) ComputeIfAbsent(key K, factory func() V) (actual V, loaded bool) {
+	if temp, ok := m.Load(key); ok {
+		return temp, true
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if temp, ok := m.bm[key]; ok {
+		return temp, true
+	}
+	actual = factory()
+	m.bm[key] = actual
+	return actual, false
+}
+
+// Merge calls f while holding the write lock, f must not access the map.
+func (m *RWMutexMap[K, V]) Merge(key K, value V, f func(old, value V) V) (actual V) {
+	actual, _ = m.Compute(key, mergeFunc(value, f))
+	return actual
+}
+
+func (m *RWMutexMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	previous, loaded = m.bm[key]
+	m.bm[key] = value
+	return previous, loaded
+}
+
+func (m *RWMutexMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	temp, ok := m.bm[key]
+	if !ok || !m.equal(temp, old) {
+		return false
+	}
+	m.bm[key] = new
+	return true
+}
+
+func (m *RWMutexMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	temp, ok := m.bm[key]
+	if !ok || !m.equal(temp, old) {
+		return false
+	}
+	delete(m.bm, key)
+	return true
+}
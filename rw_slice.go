@@ -20,44 +20,126 @@ import "sync"
 
 var (
 	_ SafeArray[int] = (*RWSlice[int])(nil)
+	_ Array[int]     = (*RWSlice[int])(nil)
 )
 
+// RWSlice is a slice guarded by a sync.RWMutex, it implements the SafeArray[V] interface
 type RWSlice[V any] struct {
-	lock *sync.RWMutex
-	bm   []V
+	lock  *sync.RWMutex
+	bm    []V
+	equal func(a, b V) bool
 }
 
-func NewRWSlice[V any]() *RWSlice[V] {
+// NewRWSlice return an RWSlice that compares values with the == operator,
+// or with reflect.DeepEqual if V may hold values that == cannot compare.
+func NewRWSlice[V any](values ...V) *RWSlice[V] {
+	return NewRWSliceFunc(defaultEqual[V](), values...)
+}
+
+// NewComparableRWSlice return an RWSlice of comparable values that compares them with the == operator
+func NewComparableRWSlice[V comparable](values ...V) *RWSlice[V] {
+	return NewRWSliceFunc(isEqual[V], values...)
+}
+
+// NewRWSliceFunc return an RWSlice that compares values with the equal function
+func NewRWSliceFunc[V any](equal func(a, b V) bool, values ...V) *RWSlice[V] {
+	bm := make([]V, len(values))
+	copy(bm, values)
 	return &RWSlice[V]{
-		lock: new(sync.RWMutex),
-		bm:   []V{},
+		lock:  new(sync.RWMutex),
+		bm:    bm,
+		equal: equal,
 	}
 }
 
-func (rw RWSlice[V]) Add(src ...V) {
+func (rw *RWSlice[V]) Add(src ...V) {
+	rw.lock.Lock()
+	defer rw.lock.Unlock()
 	rw.bm = append(rw.bm, src...)
 }
 
-func (rw RWSlice[V]) Remove(index int) {
+// Insert inserts the values at index, nothing happens if index is out of [0, Length()].
+func (rw *RWSlice[V]) Insert(index int, values ...V) {
+	rw.lock.Lock()
+	defer rw.lock.Unlock()
+	if index < 0 || index > len(rw.bm) {
+		return
+	}
+	rw.bm = insertSlice(rw.bm, index, values...)
+}
+
+func (rw *RWSlice[V]) Remove(index int) {
+	rw.RemoveRange(index, index+1)
+}
+
+// RemoveRange removes the elements in [from, to), nothing happens if the interval is invalid.
+func (rw *RWSlice[V]) RemoveRange(from, to int) {
+	rw.lock.Lock()
+	defer rw.lock.Unlock()
+	if from < 0 || to > len(rw.bm) || from >= to {
+		return
+	}
+	rw.bm = removeSlice(rw.bm, from, to)
+}
+
+func (rw *RWSlice[V]) RemoveAll() {
+	rw.lock.Lock()
+	defer rw.lock.Unlock()
+	rw.bm = []V{}
+}
+
+// Set replaces the element at index, the value is appended if index equals Length().
+func (rw *RWSlice[V]) Set(index int, v V) {
+	rw.lock.Lock()
+	defer rw.lock.Unlock()
+	if index == len(rw.bm) {
+		rw.bm = append(rw.bm, v)
+	} else if index >= 0 && index < len(rw.bm) {
+		rw.bm[index] = v
+	}
+}
+
+func (rw *RWSlice[V]) Get(index int) (V, bool) {
+	rw.lock.RLock()
+	defer rw.lock.RUnlock()
+	if index < 0 || index >= len(rw.bm) {
+		return *new(V), false
+	}
+	return rw.bm[index], true
+}
+
+func (rw *RWSlice[V]) Swap(i, j int) {
+	rw.lock.Lock()
+	defer rw.lock.Unlock()
+	if i < 0 || j < 0 || i >= len(rw.bm) || j >= len(rw.bm) {
+		return
+	}
+	rw.bm[i], rw.bm[j] = rw.bm[j], rw.bm[i]
+}
+
+func (rw *RWSlice[V]) CompareAndSet(index int, old, new V) bool {
 	rw.lock.Lock()
 	defer rw.lock.Unlock()
-	if index == 0 {
-		rw.bm = rw.bm[1:]
-	} else if index == len(rw.bm)-1 {
-		rw.bm = rw.bm[:index]
-	} else {
-		j := 0
-		for i, v := range rw.bm {
-			if i != index {
-				rw.bm[j] = v
-				j++
-			}
+	if index < 0 || index >= len(rw.bm) || !rw.equal(rw.bm[index], old) {
+		return false
+	}
+	rw.bm[index] = new
+	return true
+}
+
+func (rw *RWSlice[V]) IndexOf(value V) int {
+	rw.lock.RLock()
+	defer rw.lock.RUnlock()
+	for i, v := range rw.bm {
+		if rw.equal(v, value) {
+			return i
 		}
-		rw.bm = rw.bm[:j]
 	}
+	return -1
 }
 
-func (rw RWSlice[V]) Range(f func(index int, value V) bool) {
+// Range calls f for each element while holding the read lock, f must not modify the slice.
+func (rw *RWSlice[V]) Range(f func(index int, value V) bool) {
 	rw.lock.RLock()
 	defer rw.lock.RUnlock()
 	for i, v := range rw.bm {
@@ -67,44 +149,85 @@ func (rw RWSlice[V]) Range(f func(index int, value V) bool) {
 	}
 }
 
-func (rw RWSlice[V]) Each(f func(index int, value V)) {
+func (rw *RWSlice[V]) Each(f func(index int, value V)) {
 	rw.Range(func(i int, v1 V) bool {
 		f(i, v1)
 		return true
 	})
 }
 
-func (rw RWSlice[V]) Data() []V {
+func (rw *RWSlice[V]) Every(f func(index int, value V) bool) bool {
+	ok := true
+	rw.Range(func(i int, v1 V) bool {
+		ok = f(i, v1)
+		return ok
+	})
+	return ok
+}
+
+func (rw *RWSlice[V]) Some(f func(index int, value V) bool) bool {
+	ok := false
+	rw.Range(func(i int, v1 V) bool {
+		ok = f(i, v1)
+		return !ok
+	})
+	return ok
+}
+
+func (rw *RWSlice[V]) Values() []V {
+	return rw.Data()
+}
+
+func (rw *RWSlice[V]) Data() []V {
 	rw.lock.RLock()
 	defer rw.lock.RUnlock()
-
 	cp := make([]V, len(rw.bm))
-	for i, v := range rw.bm {
-		cp[i] = v
-	}
+	copy(cp, rw.bm)
 	return cp
 }
 
-func (rw RWSlice[V]) Set(index int, v V) {
-	rw.lock.Lock()
-	defer rw.lock.Unlock()
-	rw.bm[index] = v
+func (rw *RWSlice[V]) Empty() bool {
+	return rw.Length() == 0
 }
 
-func (rw RWSlice[V]) Get(index int) V {
-	rw.lock.RLock()
-	defer rw.lock.RUnlock()
-	return rw.bm[index]
+func (rw *RWSlice[V]) Size() int {
+	return rw.Length()
 }
 
-func (rw RWSlice[V]) Length() int {
+func (rw *RWSlice[V]) Length() int {
 	rw.lock.RLock()
 	defer rw.lock.RUnlock()
 	return len(rw.bm)
 }
 
-func (rw RWSlice[V]) Capacity() int {
+func (rw *RWSlice[V]) Capacity() int {
 	rw.lock.RLock()
 	defer rw.lock.RUnlock()
 	return cap(rw.bm)
 }
+
+// insertSlice inserts values into s at index, index must be in [0, len(s)].
+func insertSlice[V any](s []V, index int, values ...V) []V {
+	n := len(s) + len(values)
+	if n > cap(s) {
+		r := make([]V, n, n+n/4)
+		copy(r, s[:index])
+		copy(r[index:], values)
+		copy(r[index+len(values):], s[index:])
+		return r
+	}
+	s = s[:n]
+	copy(s[index+len(values):], s[index:])
+	copy(s[index:], values)
+	return s
+}
+
+// removeSlice removes the elements in [from, to) from s and clears the vacated tail.
+func removeSlice[V any](s []V, from, to int) []V {
+	n := copy(s[from:], s[to:])
+	var zero V
+	for i := from + n; i < len(s); i++ {
+		s[i] = zero
+	}
+	return s[:from+n]
+}
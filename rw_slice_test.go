@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRWSlice(t *testing.T) {
+	s := NewRWSlice(1, 2, 3)
+	s.Insert(1, 7, 8)
+	s.Add(9)
+	if want := []int{1, 7, 8, 2, 3, 9}; !reflect.DeepEqual(s.Data(), want) {
+		t.Fatalf("Data() = %v, want %v", s.Data(), want)
+	}
+	s.RemoveRange(1, 3)
+	s.Swap(0, 3)
+	if want := []int{9, 2, 3, 1}; !reflect.DeepEqual(s.Values(), want) {
+		t.Fatalf("Values() = %v, want %v", s.Values(), want)
+	}
+	if s.CompareAndSet(1, 5, 6) || !s.CompareAndSet(1, 2, 6) {
+		t.Fatalf("CompareAndSet mismatch")
+	}
+	if i := s.IndexOf(6); i != 1 {
+		t.Fatalf("IndexOf(6) = %d, want 1", i)
+	}
+	if _, ok := s.Get(4); ok {
+		t.Fatalf("Get(4) is out of range but found a value")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.Add(j)
+			}
+		}()
+	}
+	wg.Wait()
+	if s.Size() != 804 {
+		t.Fatalf("Size() = %d, want 804", s.Size())
+	}
+}
+
+func TestRWSliceUncomparableValues(t *testing.T) {
+	s := NewRWSlice[[]int]([]int{1}, []int{2, 3})
+	if i := s.IndexOf([]int{2, 3}); i != 1 {
+		t.Errorf("IndexOf([2 3]) = %d, want 1", i)
+	}
+	if !s.CompareAndSet(0, []int{1}, []int{4}) {
+		t.Errorf("CompareAndSet(0) did not set the value")
+	}
+
+	a := NewRWSlice[any](1, []int{1}, "a")
+	if i := a.IndexOf([]int{1}); i != 1 {
+		t.Errorf("IndexOf([1]) = %d, want 1", i)
+	}
+	if i := NewComparableRWSlice("a", "b").IndexOf("b"); i != 1 {
+		t.Errorf("IndexOf(b) = %d, want 1", i)
+	}
+}
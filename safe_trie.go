@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import "sync"
+
+var (
+	_ SafeMap[string, int] = (*SafeTrie[int])(nil)
+	_ Enumerable[bool]     = (*SafeTrie[bool])(nil)
+)
+
+// SafeTrie is a Trie guarded by a sync.RWMutex, it implements the SafeMap[string,V] interface
+// and answers prefix queries. Keys are enumerated in lexicographic order.
+type SafeTrie[V any] struct {
+	lock *sync.RWMutex
+	bm   *Trie[V]
+}
+
+// NewSafeTrie return a SafeTrie that splits keys into runes
+func NewSafeTrie[V any]() *SafeTrie[V] {
+	return &SafeTrie[V]{
+		lock: new(sync.RWMutex),
+		bm:   NewTrie[V](),
+	}
+}
+
+// NewSafeByteTrie return a SafeTrie that splits keys into bytes
+func NewSafeByteTrie[V any]() *SafeTrie[V] {
+	return &SafeTrie[V]{
+		lock: new(sync.RWMutex),
+		bm:   NewByteTrie[V](),
+	}
+}
+
+func (t *SafeTrie[V]) Get(key string) V {
+	val, _ := t.Load(key)
+	return val
+}
+
+func (t *SafeTrie[V]) Exist(key string) (ok bool) {
+	_, ok = t.Load(key)
+	return ok
+}
+
+func (t *SafeTrie[V]) Store(key string, value V) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.bm.Insert(key, value)
+}
+
+func (t *SafeTrie[V]) Load(key string) (value V, ok bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.bm.Get(key)
+}
+
+// Range calls f for each key in lexicographic order while holding the read lock,
+// f must not modify the trie.
+func (t *SafeTrie[V]) Range(f func(key string, value V) bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	t.bm.Range(f)
+}
+
+func (t *SafeTrie[V]) Each(f func(key string, value V)) {
+	t.Range(func(k string, v V) bool {
+		f(k, v)
+		return true
+	})
+}
+
+func (t *SafeTrie[V]) EachValue(f func(value V)) {
+	t.Range(func(_ string, v V) bool {
+		f(v)
+		return true
+	})
+}
+
+func (t *SafeTrie[V]) Keys() []string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.bm.Keys()
+}
+
+func (t *SafeTrie[V]) Values() []V {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.bm.Values()
+}
+
+func (t *SafeTrie[V]) Size() int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.bm.Size()
+}
+
+func (t *SafeTrie[V]) Delete(key string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.bm.Delete(key)
+}
+
+func (t *SafeTrie[V]) DeleteAll() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.bm.RemoveAll()
+}
+
+func (t *SafeTrie[V]) LoadOrStore(key string, value V) (actual V, loaded bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if temp, ok := t.bm.Get(key); ok {
+		return temp, true
+	}
+	t.bm.Insert(key, value)
+	return value, false
+}
+
+func (t *SafeTrie[V]) LoadAndDelete(key string) (value V, loaded bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	temp, ok := t.bm.Get(key)
+	if ok {
+		t.bm.Delete(key)
+	}
+	return temp, ok
+}
+
+// Data returns a snapshot of all entries
+func (t *SafeTrie[V]) Data() map[string]V {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	r := make(map[string]V, t.bm.Size())
+	t.bm.Each(func(k string, v V) {
+		r[k] = v
+	})
+	return r
+}
+
+// HasPrefix reports whether any key in the trie begins with prefix.
+func (t *SafeTrie[V]) HasPrefix(prefix string) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.bm.HasPrefix(prefix)
+}
+
+// LongestPrefixMatch returns the longest key in the trie that is a prefix of s.
+func (t *SafeTrie[V]) LongestPrefixMatch(s string) (key string, value V, ok bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.bm.LongestPrefixMatch(s)
+}
+
+// WalkPrefix calls f in lexicographic order for each key beginning with prefix while holding the read lock,
+// f must not modify the trie.
+func (t *SafeTrie[V]) WalkPrefix(prefix string, f func(key string, value V) bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	t.bm.WalkPrefix(prefix, f)
+}
+
+// PrefixData returns a snapshot of the entries whose keys begin with prefix
+func (t *SafeTrie[V]) PrefixData(prefix string) map[string]V {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	r := make(map[string]V)
+	t.bm.WalkPrefix(prefix, func(k string, v V) bool {
+		r[k] = v
+		return true
+	})
+	return r
+}
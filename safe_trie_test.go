@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestSafeTrie(t *testing.T) {
+	trie := NewSafeTrie[int]()
+	for i, k := range []string{"team", "tea", "ten", "to"} {
+		trie.Store(k, i)
+	}
+	if v, loaded := trie.LoadOrStore("tea", 10); !loaded || v != 1 {
+		t.Errorf("LoadOrStore(tea) = %v, %v", v, loaded)
+	}
+	if v, loaded := trie.LoadOrStore("te", 10); loaded || v != 10 || trie.Get("te") != 10 {
+		t.Errorf("LoadOrStore(te) = %v, %v", v, loaded)
+	}
+	if v, loaded := trie.LoadAndDelete("te"); !loaded || v != 10 || trie.Exist("te") {
+		t.Errorf("LoadAndDelete(te) = %v, %v", v, loaded)
+	}
+	if _, loaded := trie.LoadAndDelete("te"); loaded {
+		t.Errorf("LoadAndDelete(te) found a deleted key")
+	}
+
+	if !trie.HasPrefix("te") || trie.HasPrefix("tx") {
+		t.Errorf("HasPrefix mismatch")
+	}
+	if k, v, ok := trie.LongestPrefixMatch("teammate"); !ok || k != "team" || v != 0 {
+		t.Errorf("LongestPrefixMatch(teammate) = %q, %v, %v", k, v, ok)
+	}
+	var keys []string
+	trie.WalkPrefix("te", func(key string, _ int) bool {
+		keys = append(keys, key)
+		return len(keys) < 2
+	})
+	if want := []string{"tea", "team"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("WalkPrefix(te) = %v, want %v", keys, want)
+	}
+	if want := map[string]int{"tea": 1, "team": 0, "ten": 2}; !reflect.DeepEqual(trie.PrefixData("te"), want) {
+		t.Errorf("PrefixData(te) = %v, want %v", trie.PrefixData("te"), want)
+	}
+
+	data := trie.Data()
+	data["tea"] = 100
+	delete(data, "to")
+	if trie.Get("tea") != 1 || !trie.Exist("to") {
+		t.Errorf("modifying Data() changed the trie")
+	}
+	trie.Store("tiny", 5)
+	if _, ok := data["tiny"]; ok || len(data) != 3 {
+		t.Errorf("Data() observed a later Store")
+	}
+
+	trie.DeleteAll()
+	if trie.Size() != 0 || trie.HasPrefix("t") {
+		t.Errorf("trie is not empty after DeleteAll")
+	}
+}
+
+func TestSafeTrieConcurrent(t *testing.T) {
+	trie := NewSafeByteTrie[int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := strconv.Itoa(g) + "/" + strconv.Itoa(i)
+				trie.LoadOrStore(key, i)
+				if i%3 == 0 {
+					trie.LoadAndDelete(key)
+				}
+			}
+		}(g)
+		go func(g int) {
+			defer wg.Done()
+			prefix := strconv.Itoa(g) + "/"
+			for i := 0; i < 500; i++ {
+				trie.WalkPrefix(prefix, func(key string, _ int) bool {
+					return true
+				})
+				trie.HasPrefix(prefix)
+				trie.LongestPrefixMatch(prefix + strconv.Itoa(i))
+				trie.Data()
+			}
+		}(g)
+	}
+	wg.Wait()
+	keys := trie.Keys()
+	if len(keys) != 4*333 || trie.Size() != len(keys) {
+		t.Fatalf("size = %d, keys = %d, want %d", trie.Size(), len(keys), 4*333)
+	}
+	if !sort.StringsAreSorted(keys) {
+		t.Errorf("Keys() are not sorted")
+	}
+}
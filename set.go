@@ -0,0 +1,307 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import "sync"
+
+var (
+	_ Container       = (Set[int])(nil)
+	_ Enumerable[int] = (Set[int])(nil)
+	_ Container       = (*SafeSet[int])(nil)
+	_ Enumerable[int] = (*SafeSet[int])(nil)
+)
+
+// Set is an unordered set of comparable values backed by a Go map
+type Set[T comparable] map[T]struct{}
+
+// NewSet return a Set that contains the values
+func NewSet[T comparable](values ...T) Set[T] {
+	s := make(Set[T], len(values))
+	s.Add(values...)
+	return s
+}
+
+func (s Set[T]) Add(values ...T) {
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+}
+
+func (s Set[T]) Remove(values ...T) {
+	for _, v := range values {
+		delete(s, v)
+	}
+}
+
+func (s Set[T]) Contains(value T) bool {
+	_, ok := s[value]
+	return ok
+}
+
+func (s Set[T]) Range(f func(value T) bool) {
+	for v := range s {
+		if !f(v) {
+			break
+		}
+	}
+}
+
+func (s Set[T]) EachValue(f func(value T)) {
+	for v := range s {
+		f(v)
+	}
+}
+
+func (s Set[T]) Values() []T {
+	r := make([]T, 0, len(s))
+	for v := range s {
+		r = append(r, v)
+	}
+	return r
+}
+
+func (s Set[T]) Clone() Set[T] {
+	r := make(Set[T], len(s))
+	for v := range s {
+		r[v] = struct{}{}
+	}
+	return r
+}
+
+func (s Set[T]) Empty() bool {
+	return len(s) == 0
+}
+
+func (s Set[T]) Size() int {
+	return len(s)
+}
+
+func (s Set[T]) RemoveAll() {
+	for v := range s {
+		delete(s, v)
+	}
+}
+
+// Union returns a new set with the values that are in s or other
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	r := s.Clone()
+	for v := range other {
+		r[v] = struct{}{}
+	}
+	return r
+}
+
+// Intersect returns a new set with the values that are in both s and other
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	r := make(Set[T])
+	for v := range small {
+		if _, ok := large[v]; ok {
+			r[v] = struct{}{}
+		}
+	}
+	return r
+}
+
+// Difference returns a new set with the values that are in s but not in other
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	r := make(Set[T])
+	for v := range s {
+		if _, ok := other[v]; !ok {
+			r[v] = struct{}{}
+		}
+	}
+	return r
+}
+
+// SymmetricDifference returns a new set with the values that are in exactly one of s and other
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	r := s.Difference(other)
+	for v := range other {
+		if _, ok := s[v]; !ok {
+			r[v] = struct{}{}
+		}
+	}
+	return r
+}
+
+// IsSubset reports whether every value of s is in other
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for v := range s {
+		if _, ok := other[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether every value of other is in s
+func (s Set[T]) IsSuperset(other Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal reports whether s and other contain the same values
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// SafeSet is a Set guarded by a sync.RWMutex.
+// Operations with another SafeSet take a snapshot of the other set first, so they never hold both locks.
+type SafeSet[T comparable] struct {
+	lock *sync.RWMutex
+	bm   Set[T]
+}
+
+// NewSafeSet return a SafeSet that contains the values
+func NewSafeSet[T comparable](values ...T) *SafeSet[T] {
+	return &SafeSet[T]{
+		lock: new(sync.RWMutex),
+		bm:   NewSet(values...),
+	}
+}
+
+func (s *SafeSet[T]) Add(values ...T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.bm.Add(values...)
+}
+
+func (s *SafeSet[T]) Remove(values ...T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.bm.Remove(values...)
+}
+
+// AddIfAbsent adds the value and reports whether it was absent
+func (s *SafeSet[T]) AddIfAbsent(value T) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.bm.Contains(value) {
+		return false
+	}
+	s.bm.Add(value)
+	return true
+}
+
+func (s *SafeSet[T]) Contains(value T) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.bm.Contains(value)
+}
+
+// Range calls f for each value while holding the read lock, f must not modify the set.
+func (s *SafeSet[T]) Range(f func(value T) bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.bm.Range(f)
+}
+
+func (s *SafeSet[T]) EachValue(f func(value T)) {
+	s.Range(func(v T) bool {
+		f(v)
+		return true
+	})
+}
+
+func (s *SafeSet[T]) Values() []T {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.bm.Values()
+}
+
+// Data returns a snapshot of the set
+func (s *SafeSet[T]) Data() Set[T] {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.bm.Clone()
+}
+
+func (s *SafeSet[T]) Empty() bool {
+	return s.Size() == 0
+}
+
+func (s *SafeSet[T]) Size() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.bm)
+}
+
+func (s *SafeSet[T]) RemoveAll() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.bm = make(Set[T])
+}
+
+// Union returns a new set with the values that are in s or other
+func (s *SafeSet[T]) Union(other *SafeSet[T]) *SafeSet[T] {
+	return s.apply(other, Set[T].Union)
+}
+
+// Intersect returns a new set with the values that are in both s and other
+func (s *SafeSet[T]) Intersect(other *SafeSet[T]) *SafeSet[T] {
+	return s.apply(other, Set[T].Intersect)
+}
+
+// Difference returns a new set with the values that are in s but not in other
+func (s *SafeSet[T]) Difference(other *SafeSet[T]) *SafeSet[T] {
+	return s.apply(other, Set[T].Difference)
+}
+
+// SymmetricDifference returns a new set with the values that are in exactly one of s and other
+func (s *SafeSet[T]) SymmetricDifference(other *SafeSet[T]) *SafeSet[T] {
+	return s.apply(other, Set[T].SymmetricDifference)
+}
+
+// IsSubset reports whether every value of s is in other
+func (s *SafeSet[T]) IsSubset(other *SafeSet[T]) bool {
+	o := other.Data()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.bm.IsSubset(o)
+}
+
+// IsSuperset reports whether every value of other is in s
+func (s *SafeSet[T]) IsSuperset(other *SafeSet[T]) bool {
+	o := other.Data()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.bm.IsSuperset(o)
+}
+
+// Equal reports whether s and other contain the same values
+func (s *SafeSet[T]) Equal(other *SafeSet[T]) bool {
+	o := other.Data()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.bm.Equal(o)
+}
+
+func (s *SafeSet[T]) apply(other *SafeSet[T], op func(a, b Set[T]) Set[T]) *SafeSet[T] {
+	o := other.Data()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return &SafeSet[T]{
+		lock: new(sync.RWMutex),
+		bm:   op(s.bm, o),
+	}
+}
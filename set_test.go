@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func sortedValues(s Enumerable[int]) []int {
+	r := ConvertTo(s, func(v int) int { return v })
+	sort.Ints(r)
+	return r
+}
+
+func TestSetOperations(t *testing.T) {
+	a, b := NewSet(1, 2, 3, 4), NewSet(3, 4, 5)
+	sa, sb := NewSafeSet(1, 2, 3, 4), NewSafeSet(3, 4, 5)
+	ta, tb := NewTreeSet(1, 2, 3, 4), NewTreeSet(3, 4, 5)
+
+	cases := []struct {
+		name string
+		got  []Enumerable[int]
+		want []int
+	}{
+		{"Union", []Enumerable[int]{a.Union(b), sa.Union(sb), ta.Union(tb)}, []int{1, 2, 3, 4, 5}},
+		{"Intersect", []Enumerable[int]{a.Intersect(b), sa.Intersect(sb), ta.Intersect(tb)}, []int{3, 4}},
+		{"Difference", []Enumerable[int]{a.Difference(b), sa.Difference(sb), ta.Difference(tb)}, []int{1, 2}},
+		{"SymmetricDifference", []Enumerable[int]{a.SymmetricDifference(b), sa.SymmetricDifference(sb), ta.SymmetricDifference(tb)}, []int{1, 2, 5}},
+	}
+	for _, c := range cases {
+		for _, got := range c.got {
+			if r := sortedValues(got); !reflect.DeepEqual(r, c.want) {
+				t.Errorf("%s = %v, want %v", c.name, r, c.want)
+			}
+		}
+	}
+
+	sub := NewSet(3, 4)
+	if !sub.IsSubset(a) || !a.IsSuperset(sub) || sub.IsSubset(NewSet(3)) || !sub.Equal(NewSet(4, 3)) {
+		t.Errorf("Set subset relations mismatch")
+	}
+	if !NewSafeSet(3, 4).IsSubset(sa) || !sa.IsSuperset(NewSafeSet(4)) || sa.Equal(sb) {
+		t.Errorf("SafeSet subset relations mismatch")
+	}
+	if !NewTreeSet(3, 4).IsSubset(ta) || !ta.IsSuperset(NewTreeSet(4)) || !ta.Equal(NewTreeSet(4, 3, 2, 1)) {
+		t.Errorf("TreeSet subset relations mismatch")
+	}
+}
@@ -0,0 +1,240 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/lynnplus/gotypes/constraints"
+)
+
+var (
+	_ AtomicMap[int, int] = (*ShardedMap[int, int])(nil)
+	_ Enumerable[bool]    = (*ShardedMap[int, bool])(nil)
+)
+
+type mapShard[K constraints.Basic, V any] struct {
+	RWMutexMap[K, V]
+	lock sync.RWMutex
+	// pad keeps the locks of neighbouring shards on different cache lines
+	_ [64]byte
+}
+
+// ShardedMap implements the AtomicMap[K,V] interface by spreading keys over a power of two number of RWMutexMap shards,
+// so writers of different shards do not wait for each other.
+// Operations on a single key lock one shard, Data and Size lock all shards to observe a consistent snapshot.
+// Keys are limited to constraints.Basic because the shard is chosen by hashing the key.
+type ShardedMap[K constraints.Basic, V any] struct {
+	shards []mapShard[K, V]
+	mask   uint64
+	hash   func(key K) uint64
+}
+
+// NewShardedMap return a ShardedMap with four shards per logical CPU
+func NewShardedMap[K constraints.Basic, V any]() *ShardedMap[K, V] {
+	return NewShardedMapWithShards[K, V](4 * runtime.GOMAXPROCS(0))
+}
+
+// NewShardedMapWithShards return a ShardedMap with at least the given number of shards,
+// the number is rounded up to a power of two.
+// Values are compared with the == operator, or with reflect.DeepEqual if V may hold values that == cannot compare.
+func NewShardedMapWithShards[K constraints.Basic, V any](shards int) *ShardedMap[K, V] {
+	return NewShardedMapFunc[K, V](shards, defaultEqual[V]())
+}
+
+// NewShardedMapFunc is like NewShardedMapWithShards but compares values with the equal function
+func NewShardedMapFunc[K constraints.Basic, V any](shards int, equal func(a, b V) bool) *ShardedMap[K, V] {
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+	m := &ShardedMap[K, V]{
+		shards: make([]mapShard[K, V], n),
+		mask:   uint64(n - 1),
+		hash:   newHasher[K](),
+	}
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.RWMutexMap = RWMutexMap[K, V]{lock: &s.lock, bm: make(map[K]V), equal: equal}
+	}
+	return m
+}
+
+func (m *ShardedMap[K, V]) shard(key K) *RWMutexMap[K, V] {
+	return &m.shards[m.hash(key)&m.mask].RWMutexMap
+}
+
+// Shards returns the number of shards
+func (m *ShardedMap[K, V]) Shards() int {
+	return len(m.shards)
+}
+
+func (m *ShardedMap[K, V]) Get(key K) V {
+	return m.shard(key).Get(key)
+}
+
+func (m *ShardedMap[K, V]) Exist(key K) (ok bool) {
+	return m.shard(key).Exist(key)
+}
+
+func (m *ShardedMap[K, V]) Store(key K, value V) {
+	m.shard(key).Store(key, value)
+}
+
+func (m *ShardedMap[K, V]) Load(key K) (value V, ok bool) {
+	return m.shard(key).Load(key)
+}
+
+func (m *ShardedMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	return m.shard(key).LoadOrStore(key, value)
+}
+
+func (m *ShardedMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	return m.shard(key).LoadAndDelete(key)
+}
+
+func (m *ShardedMap[K, V]) Delete(key K) {
+	m.shard(key).Delete(key)
+}
+
+// Compute calls f while holding the write lock of the shard of the key, f must not access the map.
+func (m *ShardedMap[K, V]) Compute(key K, f func(old V, loaded bool) (value V, keep bool)) (actual V, ok bool) {
+	return m.shard(key).Compute(key, f)
+}
+
+// ComputeIfAbsent calls factory while holding the write lock of the shard of the key, factory must not access the map.
+func (m *ShardedMap[K, V]) ComputeIfAbsent(key K, factory func() V) (actual V, loaded bool) {
+	return m.shard(key).ComputeIfAbsent(key, factory)
+}
+
+// Merge calls f while holding the write lock of the shard of the key, f must not access the map.
+func (m *ShardedMap[K, V]) Merge(key K, value V, f func(old, value V) V) (actual V) {
+	return m.shard(key).Merge(key, value, f)
+}
+
+func (m *ShardedMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	return m.shard(key).Swap(key, value)
+}
+
+func (m *ShardedMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	return m.shard(key).CompareAndSwap(key, old, new)
+}
+
+func (m *ShardedMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	return m.shard(key).CompareAndDelete(key, old)
+}
+
+// Range calls f for each entry while holding the read lock of one shard at a time,
+// each shard is visited as a snapshot but writes to shards not yet visited may be observed.
+// f must not modify the map.
+func (m *ShardedMap[K, V]) Range(f func(key K, value V) bool) {
+	for i := range m.shards {
+		stopped := false
+		m.shards[i].Range(func(k K, v V) bool {
+			stopped = !f(k, v)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+func (m *ShardedMap[K, V]) Each(f func(key K, value V)) {
+	m.Range(func(k K, v V) bool {
+		f(k, v)
+		return true
+	})
+}
+
+func (m *ShardedMap[K, V]) EachValue(f func(value V)) {
+	m.Range(func(_ K, v V) bool {
+		f(v)
+		return true
+	})
+}
+
+func (m *ShardedMap[K, V]) Keys() []K {
+	m.rLockAll()
+	defer m.rUnlockAll()
+	r := make([]K, 0, m.size())
+	for i := range m.shards {
+		for k := range m.shards[i].bm {
+			r = append(r, k)
+		}
+	}
+	return r
+}
+
+func (m *ShardedMap[K, V]) Values() []V {
+	m.rLockAll()
+	defer m.rUnlockAll()
+	r := make([]V, 0, m.size())
+	for i := range m.shards {
+		for _, v := range m.shards[i].bm {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+func (m *ShardedMap[K, V]) Size() int {
+	m.rLockAll()
+	defer m.rUnlockAll()
+	return m.size()
+}
+
+func (m *ShardedMap[K, V]) DeleteAll() {
+	for i := range m.shards {
+		m.shards[i].DeleteAll()
+	}
+}
+
+// Data returns a snapshot of all entries, the shards are locked together so the snapshot is consistent.
+func (m *ShardedMap[K, V]) Data() map[K]V {
+	m.rLockAll()
+	defer m.rUnlockAll()
+	r := make(map[K]V, m.size())
+	for i := range m.shards {
+		for k, v := range m.shards[i].bm {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// rLockAll read locks the shards in index order, the fixed order keeps it from deadlocking with itself.
+func (m *ShardedMap[K, V]) rLockAll() {
+	for i := range m.shards {
+		m.shards[i].lock.RLock()
+	}
+}
+
+func (m *ShardedMap[K, V]) rUnlockAll() {
+	for i := range m.shards {
+		m.shards[i].lock.RUnlock()
+	}
+}
+
+func (m *ShardedMap[K, V]) size() int {
+	count := 0
+	for i := range m.shards {
+		count += len(m.shards[i].bm)
+	}
+	return count
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestShardedMap(t *testing.T) {
+	m := NewShardedMapWithShards[string, int](5)
+	if m.Shards() != 8 {
+		t.Fatalf("Shards() = %d, want 8", m.Shards())
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := strconv.Itoa(g*1000 + i)
+				if _, loaded := m.LoadOrStore(key, i); loaded {
+					t.Errorf("LoadOrStore(%s) found a value", key)
+				}
+				if i%2 == 1 {
+					m.Delete(key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if m.Size() != 4000 || len(m.Keys()) != 4000 || len(m.Data()) != 4000 {
+		t.Fatalf("size = %d, keys = %d", m.Size(), len(m.Keys()))
+	}
+	if v, ok := m.Load("7998"); !ok || v != 998 {
+		t.Errorf("Load(7998) = %v, %v", v, ok)
+	}
+	if v, loaded := m.LoadAndDelete("7998"); !loaded || v != 998 || m.Exist("7998") {
+		t.Errorf("LoadAndDelete(7998) = %v, %v", v, loaded)
+	}
+	m.DeleteAll()
+	if m.Size() != 0 {
+		t.Errorf("size = %d after DeleteAll", m.Size())
+	}
+}
+
+func TestShardedMapFloatKeys(t *testing.T) {
+	m := NewShardedMap[float64, int]()
+	m.Store(0.0, 1)
+	if v, ok := m.Load(math.Copysign(0, -1)); !ok || v != 1 {
+		t.Errorf("Load(-0) = %v, %v", v, ok)
+	}
+}
+
+const benchmarkMapKeys = 1 << 16
+
+func benchmarkMixed(b *testing.B, m SafeMap[int, int], writePercent int) {
+	for i := 0; i < benchmarkMapKeys; i++ {
+		m.Store(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		rnd := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			key := rnd.Intn(benchmarkMapKeys)
+			if rnd.Intn(100) < writePercent {
+				m.Store(key, key)
+			} else {
+				m.Load(key)
+			}
+		}
+	})
+}
+
+func BenchmarkMixedMaps(b *testing.B) {
+	for _, writePercent := range []int{10, 50} {
+		suffix := "/writes=" + strconv.Itoa(writePercent) + "%"
+		b.Run("RWMutexMap"+suffix, func(b *testing.B) {
+			benchmarkMixed(b, NewRWMutexMap[int, int](), writePercent)
+		})
+		b.Run("SyncMap"+suffix, func(b *testing.B) {
+			benchmarkMixed(b, NewSyncMap[int, int](), writePercent)
+		})
+		b.Run("ShardedMap"+suffix, func(b *testing.B) {
+			benchmarkMixed(b, NewShardedMap[int, int](), writePercent)
+		})
+	}
+}
@@ -17,26 +17,54 @@
 package gotypes
 
 import (
-	"github.com/lynnplus/gotypes/constraints"
+	"runtime"
 	"sync"
 	"sync/atomic"
 )
 
 var (
-	_ SafeMap[int, int] = (*SyncMap[int, int])(nil)
-	_ Enumerable[bool]  = (*SyncMap[int, bool])(nil)
+	_ AtomicMap[int, int] = (*SyncMap[int, int])(nil)
+	_ Enumerable[bool]    = (*SyncMap[int, bool])(nil)
 )
 
-// SyncMap efficiency is lower than the sync.Map, only suitable for small maps
-type SyncMap[K constraints.Basic, V any] struct {
-	instance          *sync.Map
-	cacheSize         atomic.Int64
-	unknownStoreCount atomic.Int64
+type syncMapValue[V any] struct {
+	value V
+	// expunged marks the sentinel stored in an entry that is being removed from the sync.Map
+	expunged bool
 }
 
-func NewSyncMap[K constraints.Basic, V any]() *SyncMap[K, V] {
+// syncMapEntry is the value stored in the sync.Map for each key.
+// Its pointer is nil while the key has no value, and the expunged sentinel once the entry is being removed,
+// after which the entry never changes again.
+// The pointer is only changed with compare-and-swap, the mutex is held by Compute and ComputeIfAbsent
+// so that concurrent computations of the same key run one after another instead of retrying.
+type syncMapEntry[V any] struct {
+	lock sync.Mutex
+	p    atomic.Pointer[syncMapValue[V]]
+}
+
+// SyncMap is backed by a sync.Map and implements the AtomicMap[K,V] interface.
+// It keeps an exact count of its entries, so Size is O(1). Each key is stored through an entry whose value pointer is swapped atomically,
+// which tells inserts from overwrites and deletes of present keys from deletes of absent ones.
+type SyncMap[K comparable, V any] struct {
+	instance *sync.Map
+	size     atomic.Int64
+	expunged *syncMapValue[V]
+	equal    func(a, b V) bool
+}
+
+// NewSyncMap return a SyncMap that compares values with the == operator,
+// or with reflect.DeepEqual if V may hold values that == cannot compare.
+func NewSyncMap[K comparable, V any]() *SyncMap[K, V] {
+	return NewSyncMapFunc[K, V](defaultEqual[V]())
+}
+
+// NewSyncMapFunc return a SyncMap that compares values with the equal function
+func NewSyncMapFunc[K comparable, V any](equal func(a, b V) bool) *SyncMap[K, V] {
 	return &SyncMap[K, V]{
 		instance: &sync.Map{},
+		expunged: &syncMapValue[V]{expunged: true},
+		equal:    equal,
 	}
 }
 
@@ -51,20 +79,28 @@ func (s *SyncMap[K, V]) Exist(key K) (ok bool) {
 }
 
 func (s *SyncMap[K, V]) Store(key K, value V) {
-	s.instance.Store(key, value)
-	s.unknownStoreCount.Add(1)
+	s.Swap(key, value)
 }
 
 func (s *SyncMap[K, V]) Load(key K) (value V, ok bool) {
 	v, ok := s.instance.Load(key)
-	vv, _ := v.(V)
-	return vv, ok
+	if !ok {
+		return value, false
+	}
+	p := v.(*syncMapEntry[V]).p.Load()
+	if p == nil || p == s.expunged {
+		return value, false
+	}
+	return p.value, true
 }
 
 func (s *SyncMap[K, V]) Range(f func(key K, value V) bool) {
 	s.instance.Range(func(k any, v any) bool {
-		vv, _ := v.(V)
-		return f(k.(K), vv)
+		p := v.(*syncMapEntry[V]).p.Load()
+		if p == nil || p == s.expunged {
+			return true
+		}
+		return f(k.(K), p.value)
 	})
 }
 
@@ -83,11 +119,7 @@ func (s *SyncMap[K, V]) EachValue(f func(value V)) {
 }
 
 func (s *SyncMap[K, V]) Keys() []K {
-	temp := s.Size()
-	var keys []K
-	if temp > 0 {
-		keys = make([]K, 0, temp)
-	}
+	keys := make([]K, 0, s.Size())
 	s.Each(func(k K, v V) {
 		keys = append(keys, k)
 	})
@@ -95,48 +127,36 @@ func (s *SyncMap[K, V]) Keys() []K {
 }
 
 func (s *SyncMap[K, V]) Values() []V {
-	temp := s.Size()
-	var vs []V
-	if temp > 0 {
-		vs = make([]V, 0, temp)
-	}
+	vs := make([]V, 0, s.Size())
 	s.Each(func(k K, v V) {
 		vs = append(vs, v)
 	})
 	return vs
 }
 
-// Size Does not represent the actual size of the current map, just an estimate
+// Size returns the number of entries, it is exact when no write is in progress.
 func (s *SyncMap[K, V]) Size() int {
-	temp := int(s.cacheSize.Load())
-	if temp < 0 || s.unknownStoreCount.Load() > int64(temp) {
-		temp = s.syncSize()
+	// the counter is updated after the entry, so a delete racing an insert of the same key
+	// can briefly take it below zero
+	if n := s.size.Load(); n > 0 {
+		return int(n)
 	}
-	return temp
+	return 0
 }
 
 func (s *SyncMap[K, V]) Delete(key K) {
-	if _, ok := s.instance.LoadAndDelete(key); ok {
-		s.cacheSize.Add(-1)
-	}
+	s.LoadAndDelete(key)
 }
 
 func (s *SyncMap[K, V]) DeleteAll() {
-	s.Each(func(k K, v V) {
-		s.Delete(k)
+	s.instance.Range(func(k, v any) bool {
+		s.LoadAndDelete(k.(K))
+		return true
 	})
-	s.cacheSize.Store(0)
-	s.unknownStoreCount.Store(0)
 }
 
 func (s *SyncMap[K, V]) Data() map[K]V {
-	temp := s.Size()
-	var data map[K]V
-	if temp > 0 {
-		data = make(map[K]V, temp)
-	} else {
-		data = map[K]V{}
-	}
+	data := make(map[K]V, s.Size())
 	s.Each(func(k K, v V) {
 		data[k] = v
 	})
@@ -144,30 +164,215 @@ func (s *SyncMap[K, V]) Data() map[K]V {
 }
 
 func (s *SyncMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
-	data, load := s.instance.LoadOrStore(key, value)
-	if !load {
-		s.cacheSize.Add(1)
+	var p *syncMapValue[V]
+	for {
+		e := s.entry(key)
+		old := e.p.Load()
+		for old != s.expunged {
+			if old != nil {
+				return old.value, true
+			}
+			if p == nil {
+				p = &syncMapValue[V]{value: value}
+			}
+			if e.p.CompareAndSwap(nil, p) {
+				s.size.Add(1)
+				return value, false
+			}
+			old = e.p.Load()
+		}
+		s.waitRemoved(key, e)
 	}
-	vv, _ := data.(V)
-	return vv, load
 }
 
 func (s *SyncMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
-	data, exist := s.instance.LoadAndDelete(key)
-	if exist {
-		s.cacheSize.Add(-1)
+	v, ok := s.instance.Load(key)
+	if !ok {
+		return value, false
+	}
+	e := v.(*syncMapEntry[V])
+	for {
+		old := e.p.Load()
+		if old == nil || old == s.expunged {
+			return value, false
+		}
+		if e.p.CompareAndSwap(old, nil) {
+			s.size.Add(-1)
+			s.tryExpunge(key, e)
+			return old.value, true
+		}
 	}
-	vv, _ := data.(V)
-	return vv, exist
 }
 
-func (s *SyncMap[K, V]) syncSize() int {
-	count := 0
-	s.instance.Range(func(key, value any) bool {
-		count++
-		return true
-	})
-	s.unknownStoreCount.Store(0)
-	s.cacheSize.Store(int64(count))
-	return count
+// Compute calls f while holding the lock of the key, f must not access the same key.
+// f is called again if a concurrent Store, Delete or other lock-free write changes the key before its result is stored.
+func (s *SyncMap[K, V]) Compute(key K, f func(old V, loaded bool) (value V, keep bool)) (actual V, ok bool) {
+	for {
+		e := s.entry(key)
+		e.lock.Lock()
+		actual, ok, done := s.compute(key, e, f)
+		e.lock.Unlock()
+		if done {
+			return actual, ok
+		}
+		s.waitRemoved(key, e)
+	}
+}
+
+// compute stores the result of f in the entry, it returns done as false if the entry was expunged.
+func (s *SyncMap[K, V]) compute(key K, e *syncMapEntry[V], f func(old V, loaded bool) (V, bool)) (actual V, ok, done bool) {
+	for {
+		p := e.p.Load()
+		if p == s.expunged {
+			return actual, false, false
+		}
+		var old V
+		if p != nil {
+			old = p.value
+		}
+		actual, ok = f(old, p != nil)
+		switch {
+		case ok:
+			if e.p.CompareAndSwap(p, &syncMapValue[V]{value: actual}) {
+				if p == nil {
+					s.size.Add(1)
+				}
+				return actual, true, true
+			}
+		case p == nil:
+			// the entry may have been added for this call, do not leave it behind empty
+			s.tryExpunge(key, e)
+			return *new(V), false, true
+		default:
+			if e.p.CompareAndSwap(p, nil) {
+				s.size.Add(-1)
+				s.tryExpunge(key, e)
+				return *new(V), false, true
+			}
+		}
+	}
+}
+
+// ComputeIfAbsent calls factory while holding the lock of the key, factory must not access the same key.
+func (s *SyncMap[K, V]) ComputeIfAbsent(key K, factory func() V) (actual V, loaded bool) {
+	if temp, ok := s.Load(key); ok {
+		return temp, true
+	}
+	var p *syncMapValue[V]
+	for {
+		e := s.entry(key)
+		e.lock.Lock()
+		old := e.p.Load()
+		for old != s.expunged {
+			if old != nil {
+				e.lock.Unlock()
+				return old.value, true
+			}
+			if p == nil {
+				p = &syncMapValue[V]{value: factory()}
+			}
+			if e.p.CompareAndSwap(nil, p) {
+				e.lock.Unlock()
+				s.size.Add(1)
+				return p.value, false
+			}
+			old = e.p.Load()
+		}
+		e.lock.Unlock()
+		s.waitRemoved(key, e)
+	}
+}
+
+// Merge calls f while holding the lock of the key, f must not access the same key.
+// Like Compute, f is called again if a lock-free write changes the key concurrently.
+func (s *SyncMap[K, V]) Merge(key K, value V, f func(old, value V) V) (actual V) {
+	actual, _ = s.Compute(key, mergeFunc(value, f))
+	return actual
+}
+
+func (s *SyncMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	p := &syncMapValue[V]{value: value}
+	for {
+		e := s.entry(key)
+		old := e.p.Load()
+		for old != s.expunged {
+			if e.p.CompareAndSwap(old, p) {
+				if old == nil {
+					s.size.Add(1)
+					return previous, false
+				}
+				return old.value, true
+			}
+			old = e.p.Load()
+		}
+		s.waitRemoved(key, e)
+	}
+}
+
+func (s *SyncMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	v, ok := s.instance.Load(key)
+	if !ok {
+		return false
+	}
+	e := v.(*syncMapEntry[V])
+	var p *syncMapValue[V]
+	for {
+		cur := e.p.Load()
+		if cur == nil || cur == s.expunged || !s.equal(cur.value, old) {
+			return false
+		}
+		if p == nil {
+			p = &syncMapValue[V]{value: new}
+		}
+		if e.p.CompareAndSwap(cur, p) {
+			return true
+		}
+	}
+}
+
+func (s *SyncMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	v, ok := s.instance.Load(key)
+	if !ok {
+		return false
+	}
+	e := v.(*syncMapEntry[V])
+	for {
+		cur := e.p.Load()
+		if cur == nil || cur == s.expunged || !s.equal(cur.value, old) {
+			return false
+		}
+		if e.p.CompareAndSwap(cur, nil) {
+			s.size.Add(-1)
+			s.tryExpunge(key, e)
+			return true
+		}
+	}
+}
+
+// entry returns the entry of the key, adding an empty one if there is none.
+func (s *SyncMap[K, V]) entry(key K) *syncMapEntry[V] {
+	if v, ok := s.instance.Load(key); ok {
+		return v.(*syncMapEntry[V])
+	}
+	v, _ := s.instance.LoadOrStore(key, &syncMapEntry[V]{})
+	return v.(*syncMapEntry[V])
+}
+
+// tryExpunge removes the entry from the sync.Map if it is still empty, so deleted keys do not leak entries.
+// Only the goroutine that expunges the entry deletes the key, writers that find it expunged wait for that.
+func (s *SyncMap[K, V]) tryExpunge(key K, e *syncMapEntry[V]) {
+	if e.p.CompareAndSwap(nil, s.expunged) {
+		s.instance.Delete(key)
+	}
+}
+
+// waitRemoved waits until the expunged entry is no longer the entry of the key
+func (s *SyncMap[K, V]) waitRemoved(key K, e *syncMapEntry[V]) {
+	for {
+		v, ok := s.instance.Load(key)
+		if !ok || v.(*syncMapEntry[V]) != e {
+			return
+		}
+		runtime.Gosched()
+	}
 }
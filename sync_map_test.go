@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestSyncMapSize(t *testing.T) {
+	m := NewSyncMap[int, int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for i := 0; i < 5000; i++ {
+				key := rnd.Intn(64)
+				switch rnd.Intn(4) {
+				case 0:
+					m.Store(key, i)
+				case 1:
+					m.LoadOrStore(key, i)
+				case 2:
+					m.Delete(key)
+				case 3:
+					m.LoadAndDelete(key)
+				}
+			}
+		}(int64(g))
+	}
+	wg.Wait()
+	count := 0
+	m.Each(func(int, int) {
+		count++
+	})
+	if m.Size() != count || len(m.Keys()) != count || len(m.Data()) != count {
+		t.Fatalf("Size() = %d, Range counted %d", m.Size(), count)
+	}
+
+	m.DeleteAll()
+	m.Store(1, 1)
+	m.Store(1, 2)
+	if v, loaded := m.LoadOrStore(1, 3); !loaded || v != 2 || m.Size() != 1 {
+		t.Errorf("LoadOrStore(1) = %v, %v, size %d", v, loaded, m.Size())
+	}
+	m.Delete(1)
+	m.Delete(1)
+	if m.Size() != 0 || m.Exist(1) {
+		t.Errorf("size = %d after Delete", m.Size())
+	}
+}
+
+func TestSyncMapComputeWithLockFreeWrites(t *testing.T) {
+	m := NewSyncMap[int, int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for i := 0; i < 3000; i++ {
+				key := rnd.Intn(16)
+				switch rnd.Intn(6) {
+				case 0:
+					m.Store(key, i)
+				case 1:
+					m.Delete(key)
+				case 2:
+					m.Merge(key, 1, func(old, value int) int { return old + value })
+				case 3:
+					m.Compute(key, func(old int, loaded bool) (int, bool) { return old, !loaded })
+				case 4:
+					m.ComputeIfAbsent(key, func() int { return i })
+				case 5:
+					if v, ok := m.Load(key); ok {
+						m.CompareAndDelete(key, v)
+					}
+				}
+			}
+		}(int64(g))
+	}
+	wg.Wait()
+	count := 0
+	m.Each(func(int, int) {
+		count++
+	})
+	if m.Size() != count {
+		t.Fatalf("Size() = %d, Range counted %d", m.Size(), count)
+	}
+}
+
+func TestSyncMapSizeNeverNegative(t *testing.T) {
+	m := NewSyncMap[int, int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 2000; i++ {
+				m.Store(0, i)
+				m.Delete(0)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 2000; i++ {
+				if m.Size() < 0 {
+					t.Errorf("Size() is negative")
+					return
+				}
+				m.Keys()
+				m.Values()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import "github.com/lynnplus/gotypes/constraints"
+
+// countMinSketch estimates how often a hash was recorded with four rows of saturating 4 bit counters.
+// All counters are halved once the number of recorded hashes reaches the sample size,
+// so the estimates follow recent popularity.
+type countMinSketch struct {
+	rows       [4][]uint8
+	mask       uint64
+	additions  int
+	sampleSize int
+}
+
+func newCountMinSketch(capacity int) *countMinSketch {
+	width := 16
+	for width < capacity {
+		width <<= 1
+	}
+	s := &countMinSketch{mask: uint64(width - 1), sampleSize: 10 * capacity}
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// index returns the counter of the hash in row i, the rows use independent double hashing probes.
+func (s *countMinSketch) index(h uint64, i int) uint64 {
+	return (h + uint64(i)*(h>>32|h<<32|1)) & s.mask
+}
+
+func (s *countMinSketch) increment(h uint64) {
+	added := false
+	for i := range s.rows {
+		if c := &s.rows[i][s.index(h, i)]; *c < 15 {
+			*c++
+			added = true
+		}
+	}
+	if added {
+		s.additions++
+		if s.additions >= s.sampleSize {
+			s.reset()
+		}
+	}
+}
+
+func (s *countMinSketch) estimate(h uint64) uint8 {
+	min := uint8(15)
+	for i := range s.rows {
+		if c := s.rows[i][s.index(h, i)]; c < min {
+			min = c
+		}
+	}
+	return min
+}
+
+func (s *countMinSketch) reset() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+	s.additions /= 2
+}
+
+func (s *countMinSketch) clear() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] = 0
+		}
+	}
+	s.additions = 0
+}
+
+// tinyLFUPolicy implements W-TinyLFU: new entries enter a small LRU window,
+// entries leaving the window compete for a place in the main segmented LRU against its victim,
+// and the one with the higher estimated frequency is kept.
+// The main area is split into probation and protected segments, a hit in probation promotes the entry to protected.
+type tinyLFUPolicy[K constraints.Basic, V any] struct {
+	size         int
+	windowSize   int
+	protectedCap int
+	hash         func(key K) uint64
+	sketch       *countMinSketch
+	window       *LinkedHashMap[K, V]
+	probation    *LinkedHashMap[K, V]
+	protected    *LinkedHashMap[K, V]
+}
+
+// NewTinyLFUCache return a Cache that uses the W-TinyLFU admission policy,
+// it keeps frequently used entries under scans and bursts of keys that are used once.
+// onEvict is called for each entry evicted or rejected by the admission policy and may be nil.
+// It panics if capacity is not positive.
+func NewTinyLFUCache[K constraints.Basic, V any](capacity int, onEvict func(key K, value V)) Cache[K, V] {
+	checkCacheCapacity(capacity)
+	windowSize := capacity / 100
+	if windowSize < 1 {
+		windowSize = 1
+	}
+	return newPolicyCache[K, V](&tinyLFUPolicy[K, V]{
+		size:         capacity,
+		windowSize:   windowSize,
+		protectedCap: (capacity - windowSize) * 4 / 5,
+		hash:         newHasher[K](),
+		sketch:       newCountMinSketch(capacity),
+		window:       NewLinkedHashMap[K, V](),
+		probation:    NewLinkedHashMap[K, V](),
+		protected:    NewLinkedHashMap[K, V](),
+	}, onEvict)
+}
+
+func (p *tinyLFUPolicy[K, V]) get(key K) (V, bool) {
+	p.sketch.increment(p.hash(key))
+	if v, ok := p.window.Peek(key); ok {
+		p.window.MoveToEnd(key)
+		return v, true
+	}
+	if v, ok := p.protected.Peek(key); ok {
+		p.protected.MoveToEnd(key)
+		return v, true
+	}
+	if v, ok := p.probation.Peek(key); ok {
+		p.probation.Delete(key)
+		p.promote(key, v)
+		return v, true
+	}
+	return *new(V), false
+}
+
+func (p *tinyLFUPolicy[K, V]) peek(key K) (V, bool) {
+	if v, ok := p.window.Peek(key); ok {
+		return v, true
+	}
+	if v, ok := p.protected.Peek(key); ok {
+		return v, true
+	}
+	return p.probation.Peek(key)
+}
+
+func (p *tinyLFUPolicy[K, V]) add(key K, value V, evict func(key K, value V)) {
+	if _, ok := p.get(key); ok {
+		for _, segment := range []*LinkedHashMap[K, V]{p.window, p.probation, p.protected} {
+			if segment.Exist(key) {
+				segment.Store(key, value)
+			}
+		}
+		return
+	}
+	p.window.Store(key, value)
+	if p.window.Size() <= p.windowSize {
+		return
+	}
+	k, v, _ := p.window.First()
+	p.window.Delete(k)
+	p.admit(k, v, evict)
+}
+
+// promote moves an entry from probation to protected, demoting the LRU entry of protected if it is full
+func (p *tinyLFUPolicy[K, V]) promote(key K, value V) {
+	p.protected.Store(key, value)
+	if p.protected.Size() > p.protectedCap {
+		k, v, _ := p.protected.First()
+		p.protected.Delete(k)
+		p.probation.Store(k, v)
+	}
+}
+
+// admit adds the candidate evicted from the window to probation if the main area has room
+// or if it is used more often than the victim of the main area.
+func (p *tinyLFUPolicy[K, V]) admit(key K, value V, evict func(key K, value V)) {
+	if p.probation.Size()+p.protected.Size() < p.size-p.windowSize {
+		p.probation.Store(key, value)
+		return
+	}
+	victims := p.probation
+	if victims.Size() == 0 {
+		victims = p.protected
+	}
+	vk, vv, ok := victims.First()
+	if !ok || p.sketch.estimate(p.hash(key)) <= p.sketch.estimate(p.hash(vk)) {
+		evict(key, value)
+		return
+	}
+	victims.Delete(vk)
+	evict(vk, vv)
+	p.probation.Store(key, value)
+}
+
+func (p *tinyLFUPolicy[K, V]) remove(key K) (V, bool) {
+	for _, segment := range []*LinkedHashMap[K, V]{p.window, p.probation, p.protected} {
+		if v, ok := segment.Peek(key); ok {
+			segment.Delete(key)
+			return v, true
+		}
+	}
+	return *new(V), false
+}
+
+// rangeEntries visits the window, probation and protected segments, each from LRU to MRU
+func (p *tinyLFUPolicy[K, V]) rangeEntries(f func(key K, value V) bool) {
+	stopped := false
+	for _, segment := range []*LinkedHashMap[K, V]{p.window, p.probation, p.protected} {
+		segment.Range(func(k K, v V) bool {
+			stopped = !f(k, v)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+func (p *tinyLFUPolicy[K, V]) len() int {
+	return p.window.Size() + p.probation.Size() + p.protected.Size()
+}
+
+func (p *tinyLFUPolicy[K, V]) capacity() int {
+	return p.size
+}
+
+func (p *tinyLFUPolicy[K, V]) clear() {
+	p.sketch.clear()
+	p.window.DeleteAll()
+	p.probation.DeleteAll()
+	p.protected.DeleteAll()
+}
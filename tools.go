@@ -16,6 +16,12 @@
 
 package gotypes
 
+import (
+	"reflect"
+
+	"github.com/lynnplus/gotypes/constraints"
+)
+
 type Sizer interface {
 	Size() int
 }
@@ -55,3 +61,58 @@ func ConvertToWithIndex[V any, R any](source Enumerable[V], convert func(int, V)
 	})
 	return r
 }
+
+// isEqual compares a and b with the == operator, it is the default comparer of containers of comparable values.
+func isEqual[T comparable](a, b T) bool {
+	return a == b
+}
+
+// defaultEqual returns the comparer of containers whose values are not known to be comparable.
+// It uses the == operator if no value of V can make it panic, and reflect.DeepEqual otherwise,
+// so slices, maps and interfaces holding them are compared by content.
+func defaultEqual[V any]() func(a, b V) bool {
+	if strictlyComparable(reflect.TypeOf((*V)(nil)).Elem()) {
+		return func(a, b V) bool {
+			return any(a) == any(b)
+		}
+	}
+	return func(a, b V) bool {
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// strictlyComparable reports whether == can compare any two values of t without panicking,
+// which is not the case for interfaces since they may hold values of uncomparable types.
+func strictlyComparable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return false
+	case reflect.Array:
+		return strictlyComparable(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !strictlyComparable(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return t.Comparable()
+	}
+}
+
+// Compare returns -1 if a is less than b, 0 if they are equal and +1 if a is greater than b.
+// A NaN is considered less than any non-NaN, and equal to another NaN.
+func Compare[T constraints.Ordered](a, b T) int {
+	aNaN := a != a
+	bNaN := b != b
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN || a < b:
+		return -1
+	case bNaN || a > b:
+		return +1
+	}
+	return 0
+}
@@ -0,0 +1,512 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import "github.com/lynnplus/gotypes/constraints"
+
+var (
+	_ Map[int, int]         = (*TreeMap[int, int])(nil)
+	_ Enumerable[bool]      = (*TreeMap[int, bool])(nil)
+	_ Enumerable2[int, int] = (*TreeMap[int, int])(nil)
+	_ Container             = (*TreeMap[int, int])(nil)
+)
+
+type treeNode[K any, V any] struct {
+	key    K
+	value  V
+	left   *treeNode[K, V]
+	right  *treeNode[K, V]
+	parent *treeNode[K, V]
+	red    bool
+}
+
+func (n *treeNode[K, V]) min() *treeNode[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (n *treeNode[K, V]) max() *treeNode[K, V] {
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+func (n *treeNode[K, V]) next() *treeNode[K, V] {
+	if n.right != nil {
+		return n.right.min()
+	}
+	p := n.parent
+	for p != nil && n == p.right {
+		n, p = p, p.parent
+	}
+	return p
+}
+
+func (n *treeNode[K, V]) prev() *treeNode[K, V] {
+	if n.left != nil {
+		return n.left.max()
+	}
+	p := n.parent
+	for p != nil && n == p.left {
+		n, p = p, p.parent
+	}
+	return p
+}
+
+func isRed[K any, V any](n *treeNode[K, V]) bool {
+	return n != nil && n.red
+}
+
+// TreeMap implements the Map[K,V] interface with a red-black tree,
+// entries are kept sorted by key and are enumerated in ascending order.
+// Keys are ordered by their natural order when created by NewTreeMap, or by a comparator when created by NewTreeMapFunc.
+type TreeMap[K comparable, V any] struct {
+	root *treeNode[K, V]
+	size int
+	cmp  func(a, b K) int
+}
+
+// NewTreeMap return an empty TreeMap ordered by the natural order of keys
+func NewTreeMap[K constraints.Ordered, V any]() *TreeMap[K, V] {
+	return NewTreeMapFunc[K, V](Compare[K])
+}
+
+// NewTreeMapFunc return an empty TreeMap ordered by cmp,
+// cmp returns a negative number if a < b, zero if a == b and a positive number if a > b.
+// Keys that cmp considers equal are the same key.
+func NewTreeMapFunc[K comparable, V any](cmp func(a, b K) int) *TreeMap[K, V] {
+	return &TreeMap[K, V]{cmp: cmp}
+}
+
+func (t *TreeMap[K, V]) Get(key K) V {
+	val, _ := t.Load(key)
+	return val
+}
+
+func (t *TreeMap[K, V]) Exist(key K) (ok bool) {
+	return t.find(key) != nil
+}
+
+func (t *TreeMap[K, V]) Store(key K, value V) {
+	var parent *treeNode[K, V]
+	n, c := t.root, 0
+	for n != nil {
+		parent = n
+		c = t.cmp(key, n.key)
+		if c == 0 {
+			n.value = value
+			return
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	z := &treeNode[K, V]{key: key, value: value, parent: parent, red: true}
+	if parent == nil {
+		t.root = z
+	} else if c < 0 {
+		parent.left = z
+	} else {
+		parent.right = z
+	}
+	t.size++
+	t.insertFixup(z)
+}
+
+func (t *TreeMap[K, V]) Load(key K) (value V, ok bool) {
+	if n := t.find(key); n != nil {
+		return n.value, true
+	}
+	return value, false
+}
+
+// Range calls f for each entry in ascending key order, f must not modify the map.
+func (t *TreeMap[K, V]) Range(f func(key K, value V) bool) {
+	if t.root == nil {
+		return
+	}
+	for n := t.root.min(); n != nil; n = n.next() {
+		if !f(n.key, n.value) {
+			break
+		}
+	}
+}
+
+// DescendingRange calls f for each entry in descending key order, f must not modify the map.
+func (t *TreeMap[K, V]) DescendingRange(f func(key K, value V) bool) {
+	if t.root == nil {
+		return
+	}
+	for n := t.root.max(); n != nil; n = n.prev() {
+		if !f(n.key, n.value) {
+			break
+		}
+	}
+}
+
+// RangeFrom calls f in ascending key order for each entry whose key is in the half-open interval [lo, hi),
+// f must not modify the map.
+func (t *TreeMap[K, V]) RangeFrom(lo, hi K, f func(key K, value V) bool) {
+	for n := t.ceiling(lo); n != nil && t.cmp(n.key, hi) < 0; n = n.next() {
+		if !f(n.key, n.value) {
+			break
+		}
+	}
+}
+
+func (t *TreeMap[K, V]) Each(f func(key K, value V)) {
+	t.Range(func(k1 K, v1 V) bool {
+		f(k1, v1)
+		return true
+	})
+}
+
+func (t *TreeMap[K, V]) EachValue(f func(value V)) {
+	t.Range(func(_ K, v1 V) bool {
+		f(v1)
+		return true
+	})
+}
+
+func (t *TreeMap[K, V]) Every(f func(key K, value V) bool) bool {
+	ok := true
+	t.Range(func(k1 K, v1 V) bool {
+		ok = f(k1, v1)
+		return ok
+	})
+	return ok
+}
+
+func (t *TreeMap[K, V]) Some(f func(key K, value V) bool) bool {
+	ok := false
+	t.Range(func(k1 K, v1 V) bool {
+		ok = f(k1, v1)
+		return !ok
+	})
+	return ok
+}
+
+// Keys returns all keys in ascending order
+func (t *TreeMap[K, V]) Keys() []K {
+	r := make([]K, 0, t.size)
+	t.Each(func(k K, _ V) {
+		r = append(r, k)
+	})
+	return r
+}
+
+// Values returns all values in ascending order of their keys
+func (t *TreeMap[K, V]) Values() []V {
+	r := make([]V, 0, t.size)
+	t.EachValue(func(v V) {
+		r = append(r, v)
+	})
+	return r
+}
+
+func (t *TreeMap[K, V]) Size() int {
+	return t.size
+}
+
+func (t *TreeMap[K, V]) Empty() bool {
+	return t.size == 0
+}
+
+func (t *TreeMap[K, V]) Delete(key K) {
+	if n := t.find(key); n != nil {
+		t.deleteNode(n)
+	}
+}
+
+func (t *TreeMap[K, V]) DeleteAll() {
+	t.root = nil
+	t.size = 0
+}
+
+func (t *TreeMap[K, V]) RemoveAll() {
+	t.DeleteAll()
+}
+
+func (t *TreeMap[K, V]) Data() map[K]V {
+	r := make(map[K]V, t.size)
+	t.Each(func(k K, v V) {
+		r[k] = v
+	})
+	return r
+}
+
+// Min returns the entry with the least key, ok is false if the map is empty.
+func (t *TreeMap[K, V]) Min() (key K, value V, ok bool) {
+	if t.root == nil {
+		return key, value, false
+	}
+	return entryOf(t.root.min())
+}
+
+// Max returns the entry with the greatest key, ok is false if the map is empty.
+func (t *TreeMap[K, V]) Max() (key K, value V, ok bool) {
+	if t.root == nil {
+		return key, value, false
+	}
+	return entryOf(t.root.max())
+}
+
+// Floor returns the entry with the greatest key less than or equal to the given key.
+func (t *TreeMap[K, V]) Floor(key K) (K, V, bool) {
+	return entryOf(t.floor(key))
+}
+
+// Ceiling returns the entry with the least key greater than or equal to the given key.
+func (t *TreeMap[K, V]) Ceiling(key K) (K, V, bool) {
+	return entryOf(t.ceiling(key))
+}
+
+// Lower returns the entry with the greatest key strictly less than the given key.
+func (t *TreeMap[K, V]) Lower(key K) (K, V, bool) {
+	var best *treeNode[K, V]
+	for n := t.root; n != nil; {
+		if t.cmp(key, n.key) <= 0 {
+			n = n.left
+		} else {
+			best, n = n, n.right
+		}
+	}
+	return entryOf(best)
+}
+
+// Higher returns the entry with the least key strictly greater than the given key.
+func (t *TreeMap[K, V]) Higher(key K) (K, V, bool) {
+	var best *treeNode[K, V]
+	for n := t.root; n != nil; {
+		if t.cmp(key, n.key) >= 0 {
+			n = n.right
+		} else {
+			best, n = n, n.left
+		}
+	}
+	return entryOf(best)
+}
+
+func entryOf[K any, V any](n *treeNode[K, V]) (key K, value V, ok bool) {
+	if n == nil {
+		return key, value, false
+	}
+	return n.key, n.value, true
+}
+
+func (t *TreeMap[K, V]) find(key K) *treeNode[K, V] {
+	for n := t.root; n != nil; {
+		c := t.cmp(key, n.key)
+		if c == 0 {
+			return n
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return nil
+}
+
+func (t *TreeMap[K, V]) floor(key K) *treeNode[K, V] {
+	var best *treeNode[K, V]
+	for n := t.root; n != nil; {
+		c := t.cmp(key, n.key)
+		if c == 0 {
+			return n
+		}
+		if c < 0 {
+			n = n.left
+		} else {
+			best, n = n, n.right
+		}
+	}
+	return best
+}
+
+func (t *TreeMap[K, V]) ceiling(key K) *treeNode[K, V] {
+	var best *treeNode[K, V]
+	for n := t.root; n != nil; {
+		c := t.cmp(key, n.key)
+		if c == 0 {
+			return n
+		}
+		if c > 0 {
+			n = n.right
+		} else {
+			best, n = n, n.left
+		}
+	}
+	return best
+}
+
+func (t *TreeMap[K, V]) rotateLeft(x *treeNode[K, V]) {
+	y := x.right
+	x.right = y.left
+	if y.left != nil {
+		y.left.parent = x
+	}
+	t.replaceChild(x, y)
+	y.left = x
+	x.parent = y
+}
+
+func (t *TreeMap[K, V]) rotateRight(x *treeNode[K, V]) {
+	y := x.left
+	x.left = y.right
+	if y.right != nil {
+		y.right.parent = x
+	}
+	t.replaceChild(x, y)
+	y.right = x
+	x.parent = y
+}
+
+// replaceChild puts v in the position of u under u's parent, v may be nil.
+func (t *TreeMap[K, V]) replaceChild(u, v *treeNode[K, V]) {
+	if u.parent == nil {
+		t.root = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+func (t *TreeMap[K, V]) insertFixup(z *treeNode[K, V]) {
+	for isRed(z.parent) {
+		p := z.parent
+		g := p.parent
+		if p == g.left {
+			if u := g.right; isRed(u) {
+				p.red, u.red, g.red = false, false, true
+				z = g
+				continue
+			}
+			if z == p.right {
+				z, p = p, z
+				t.rotateLeft(z)
+			}
+			p.red, g.red = false, true
+			t.rotateRight(g)
+		} else {
+			if u := g.left; isRed(u) {
+				p.red, u.red, g.red = false, false, true
+				z = g
+				continue
+			}
+			if z == p.left {
+				z, p = p, z
+				t.rotateRight(z)
+			}
+			p.red, g.red = false, true
+			t.rotateLeft(g)
+		}
+	}
+	t.root.red = false
+}
+
+func (t *TreeMap[K, V]) deleteNode(z *treeNode[K, V]) {
+	var x, xParent *treeNode[K, V]
+	removedRed := z.red
+	switch {
+	case z.left == nil:
+		x, xParent = z.right, z.parent
+		t.replaceChild(z, z.right)
+	case z.right == nil:
+		x, xParent = z.left, z.parent
+		t.replaceChild(z, z.left)
+	default:
+		y := z.right.min()
+		removedRed = y.red
+		x = y.right
+		if y.parent == z {
+			xParent = y
+		} else {
+			xParent = y.parent
+			t.replaceChild(y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		t.replaceChild(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.red = z.red
+	}
+	z.left, z.right, z.parent = nil, nil, nil
+	t.size--
+	if !removedRed {
+		t.deleteFixup(x, xParent)
+	}
+}
+
+func (t *TreeMap[K, V]) deleteFixup(x, parent *treeNode[K, V]) {
+	for x != t.root && !isRed(x) {
+		if x == parent.left {
+			w := parent.right
+			if isRed(w) {
+				w.red, parent.red = false, true
+				t.rotateLeft(parent)
+				w = parent.right
+			}
+			if !isRed(w.left) && !isRed(w.right) {
+				w.red = true
+				x, parent = parent, parent.parent
+				continue
+			}
+			if !isRed(w.right) {
+				w.left.red, w.red = false, true
+				t.rotateRight(w)
+				w = parent.right
+			}
+			w.red, parent.red, w.right.red = parent.red, false, false
+			t.rotateLeft(parent)
+		} else {
+			w := parent.left
+			if isRed(w) {
+				w.red, parent.red = false, true
+				t.rotateRight(parent)
+				w = parent.left
+			}
+			if !isRed(w.left) && !isRed(w.right) {
+				w.red = true
+				x, parent = parent, parent.parent
+				continue
+			}
+			if !isRed(w.left) {
+				w.right.red, w.red = false, true
+				t.rotateLeft(w)
+				w = parent.left
+			}
+			w.red, parent.red, w.left.red = parent.red, false, false
+			t.rotateRight(parent)
+		}
+		x = t.root
+	}
+	if x != nil {
+		x.red = false
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// checkRedBlack verifies the red-black properties and returns the black height of n.
+func checkRedBlack[K any, V any](t *testing.T, n *treeNode[K, V]) int {
+	if n == nil {
+		return 1
+	}
+	if n.red && (isRed(n.left) || isRed(n.right)) {
+		t.Fatalf("red node has a red child")
+	}
+	if (n.left != nil && n.left.parent != n) || (n.right != nil && n.right.parent != n) {
+		t.Fatalf("broken parent link")
+	}
+	l, r := checkRedBlack(t, n.left), checkRedBlack(t, n.right)
+	if l != r {
+		t.Fatalf("black height mismatch: %d != %d", l, r)
+	}
+	if !n.red {
+		l++
+	}
+	return l
+}
+
+func TestTreeMap(t *testing.T) {
+	tree := NewTreeMap[int, int]()
+	expected := map[int]int{}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		k := rnd.Intn(500)
+		if rnd.Intn(3) == 0 {
+			tree.Delete(k)
+			delete(expected, k)
+		} else {
+			tree.Store(k, i)
+			expected[k] = i
+		}
+		if tree.Size() != len(expected) {
+			t.Fatalf("size = %d, want %d", tree.Size(), len(expected))
+		}
+	}
+	if isRed(tree.root) {
+		t.Fatalf("root is red")
+	}
+	checkRedBlack(t, tree.root)
+
+	keys := make([]int, 0, len(expected))
+	for k := range expected {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	if !reflect.DeepEqual(tree.Keys(), keys) {
+		t.Fatalf("Keys() are not sorted")
+	}
+	if !reflect.DeepEqual(tree.Data(), expected) {
+		t.Fatalf("Data() mismatch")
+	}
+}
+
+func TestTreeMapNavigation(t *testing.T) {
+	tree := NewTreeMap[int, string]()
+	for _, k := range []int{10, 20, 30, 40} {
+		tree.Store(k, "")
+	}
+	check := func(name string, f func(int) (int, string, bool), key, want int, wantOk bool) {
+		if k, _, ok := f(key); ok != wantOk || (ok && k != want) {
+			t.Errorf("%s(%d) = %d, %v, want %d, %v", name, key, k, ok, want, wantOk)
+		}
+	}
+	check("Floor", tree.Floor, 25, 20, true)
+	check("Floor", tree.Floor, 20, 20, true)
+	check("Floor", tree.Floor, 5, 0, false)
+	check("Ceiling", tree.Ceiling, 25, 30, true)
+	check("Ceiling", tree.Ceiling, 45, 0, false)
+	check("Lower", tree.Lower, 20, 10, true)
+	check("Higher", tree.Higher, 20, 30, true)
+	check("Higher", tree.Higher, 40, 0, false)
+
+	if k, _, _ := tree.Min(); k != 10 {
+		t.Errorf("Min() = %d", k)
+	}
+	if k, _, _ := tree.Max(); k != 40 {
+		t.Errorf("Max() = %d", k)
+	}
+
+	var ranged, descending []int
+	tree.RangeFrom(15, 40, func(k int, _ string) bool {
+		ranged = append(ranged, k)
+		return true
+	})
+	tree.DescendingRange(func(k int, _ string) bool {
+		descending = append(descending, k)
+		return true
+	})
+	if !reflect.DeepEqual(ranged, []int{20, 30}) || !reflect.DeepEqual(descending, []int{40, 30, 20, 10}) {
+		t.Errorf("RangeFrom = %v, DescendingRange = %v", ranged, descending)
+	}
+}
+
+func TestTreeMapFunc(t *testing.T) {
+	type point struct{ X, Y int }
+	byXThenY := func(a, b point) int {
+		if c := Compare(a.X, b.X); c != 0 {
+			return c
+		}
+		return Compare(a.Y, b.Y)
+	}
+	pt := func(x, y int) point { return point{x, y} }
+	tree := NewTreeMapFunc[point, string](byXThenY)
+	tree.Store(pt(2, 1), "c")
+	tree.Store(pt(1, 5), "b")
+	tree.Store(pt(1, 2), "a")
+	if !reflect.DeepEqual(tree.Values(), []string{"a", "b", "c"}) {
+		t.Fatalf("Values() = %v", tree.Values())
+	}
+	if k, _, ok := tree.Floor(pt(1, 9)); !ok || k != pt(1, 5) {
+		t.Fatalf("Floor = %v, %v", k, ok)
+	}
+
+	set := NewTreeSetFunc(byXThenY, pt(3, 3), pt(0, 0), pt(3, 3))
+	if set.Size() != 2 {
+		t.Fatalf("TreeSet size = %d, want 2", set.Size())
+	}
+	if v, ok := set.Higher(pt(0, 0)); !ok || v != pt(3, 3) {
+		t.Fatalf("Higher = %v, %v", v, ok)
+	}
+}
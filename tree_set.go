@@ -0,0 +1,248 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import "github.com/lynnplus/gotypes/constraints"
+
+var (
+	_ Container             = (*TreeSet[int])(nil)
+	_ Enumerable[int]       = (*TreeSet[int])(nil)
+	_ Enumerable2[int, int] = (*TreeSet[int])(nil)
+)
+
+// TreeSet is a sorted set backed by a TreeMap, values are enumerated in ascending order.
+type TreeSet[T comparable] struct {
+	tree *TreeMap[T, struct{}]
+}
+
+// NewTreeSet return a TreeSet ordered by the natural order of values
+func NewTreeSet[T constraints.Ordered](values ...T) *TreeSet[T] {
+	return NewTreeSetFunc(Compare[T], values...)
+}
+
+// NewTreeSetFunc return a TreeSet ordered by cmp, values that cmp considers equal are the same value.
+func NewTreeSetFunc[T comparable](cmp func(a, b T) int, values ...T) *TreeSet[T] {
+	s := &TreeSet[T]{tree: NewTreeMapFunc[T, struct{}](cmp)}
+	s.Add(values...)
+	return s
+}
+
+func (s *TreeSet[T]) Add(values ...T) {
+	for _, v := range values {
+		s.tree.Store(v, struct{}{})
+	}
+}
+
+func (s *TreeSet[T]) Remove(values ...T) {
+	for _, v := range values {
+		s.tree.Delete(v)
+	}
+}
+
+func (s *TreeSet[T]) Contains(value T) bool {
+	return s.tree.Exist(value)
+}
+
+// Min returns the least value, ok is false if the set is empty.
+func (s *TreeSet[T]) Min() (T, bool) {
+	v, _, ok := s.tree.Min()
+	return v, ok
+}
+
+// Max returns the greatest value, ok is false if the set is empty.
+func (s *TreeSet[T]) Max() (T, bool) {
+	v, _, ok := s.tree.Max()
+	return v, ok
+}
+
+// Floor returns the greatest value less than or equal to the given value.
+func (s *TreeSet[T]) Floor(value T) (T, bool) {
+	v, _, ok := s.tree.Floor(value)
+	return v, ok
+}
+
+// Ceiling returns the least value greater than or equal to the given value.
+func (s *TreeSet[T]) Ceiling(value T) (T, bool) {
+	v, _, ok := s.tree.Ceiling(value)
+	return v, ok
+}
+
+// Lower returns the greatest value strictly less than the given value.
+func (s *TreeSet[T]) Lower(value T) (T, bool) {
+	v, _, ok := s.tree.Lower(value)
+	return v, ok
+}
+
+// Higher returns the least value strictly greater than the given value.
+func (s *TreeSet[T]) Higher(value T) (T, bool) {
+	v, _, ok := s.tree.Higher(value)
+	return v, ok
+}
+
+// RangeFrom calls f in ascending order for each value in the half-open interval [lo, hi),
+// f must not modify the set.
+func (s *TreeSet[T]) RangeFrom(lo, hi T, f func(value T) bool) {
+	s.tree.RangeFrom(lo, hi, func(v T, _ struct{}) bool {
+		return f(v)
+	})
+}
+
+// DescendingRange calls f for each value in descending order, f must not modify the set.
+func (s *TreeSet[T]) DescendingRange(f func(value T) bool) {
+	s.tree.DescendingRange(func(v T, _ struct{}) bool {
+		return f(v)
+	})
+}
+
+// Range calls f for each value in ascending order with its rank, f must not modify the set.
+func (s *TreeSet[T]) Range(f func(index int, value T) bool) {
+	index := 0
+	s.tree.Range(func(v T, _ struct{}) bool {
+		ok := f(index, v)
+		index++
+		return ok
+	})
+}
+
+func (s *TreeSet[T]) Each(f func(index int, value T)) {
+	s.Range(func(i int, v T) bool {
+		f(i, v)
+		return true
+	})
+}
+
+func (s *TreeSet[T]) EachValue(f func(value T)) {
+	s.Range(func(_ int, v T) bool {
+		f(v)
+		return true
+	})
+}
+
+func (s *TreeSet[T]) Every(f func(index int, value T) bool) bool {
+	ok := true
+	s.Range(func(i int, v T) bool {
+		ok = f(i, v)
+		return ok
+	})
+	return ok
+}
+
+func (s *TreeSet[T]) Some(f func(index int, value T) bool) bool {
+	ok := false
+	s.Range(func(i int, v T) bool {
+		ok = f(i, v)
+		return !ok
+	})
+	return ok
+}
+
+// Values returns all values in ascending order
+func (s *TreeSet[T]) Values() []T {
+	return s.tree.Keys()
+}
+
+func (s *TreeSet[T]) Empty() bool {
+	return s.tree.Empty()
+}
+
+func (s *TreeSet[T]) Size() int {
+	return s.tree.Size()
+}
+
+func (s *TreeSet[T]) RemoveAll() {
+	s.tree.RemoveAll()
+}
+
+// Union returns a new set with the values that are in s or other, ordered like s.
+// It merges the two sorted sets, so other must be ordered by the same comparator as s or the result is wrong.
+func (s *TreeSet[T]) Union(other *TreeSet[T]) *TreeSet[T] {
+	return s.merge(other, true, true, true)
+}
+
+// Intersect returns a new set with the values that are in both s and other, ordered like s.
+// It merges the two sorted sets, so other must be ordered by the same comparator as s or the result is wrong.
+func (s *TreeSet[T]) Intersect(other *TreeSet[T]) *TreeSet[T] {
+	return s.merge(other, false, true, false)
+}
+
+// Difference returns a new set with the values that are in s but not in other, ordered like s.
+// It merges the two sorted sets, so other must be ordered by the same comparator as s or the result is wrong.
+func (s *TreeSet[T]) Difference(other *TreeSet[T]) *TreeSet[T] {
+	return s.merge(other, true, false, false)
+}
+
+// SymmetricDifference returns a new set with the values that are in exactly one of s and other, ordered like s.
+// It merges the two sorted sets, so other must be ordered by the same comparator as s or the result is wrong.
+func (s *TreeSet[T]) SymmetricDifference(other *TreeSet[T]) *TreeSet[T] {
+	return s.merge(other, true, false, true)
+}
+
+// IsSubset reports whether every value of s is in other
+func (s *TreeSet[T]) IsSubset(other *TreeSet[T]) bool {
+	if s.Size() > other.Size() {
+		return false
+	}
+	return s.tree.Every(func(v T, _ struct{}) bool {
+		return other.Contains(v)
+	})
+}
+
+// IsSuperset reports whether every value of other is in s
+func (s *TreeSet[T]) IsSuperset(other *TreeSet[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal reports whether s and other contain the same values
+func (s *TreeSet[T]) Equal(other *TreeSet[T]) bool {
+	return s.Size() == other.Size() && s.IsSubset(other)
+}
+
+// merge walks both sets in order and keeps the values that are only in s, in both sets, or only in other.
+// The sets must be ordered by the same comparator.
+func (s *TreeSet[T]) merge(other *TreeSet[T], onlyS, both, onlyOther bool) *TreeSet[T] {
+	cmp := s.tree.cmp
+	r := NewTreeSetFunc(cmp)
+	a, b := s.Values(), other.Values()
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := cmp(a[i], b[j]); {
+		case c < 0:
+			if onlyS {
+				r.Add(a[i])
+			}
+			i++
+		case c > 0:
+			if onlyOther {
+				r.Add(b[j])
+			}
+			j++
+		default:
+			if both {
+				r.Add(a[i])
+			}
+			i++
+			j++
+		}
+	}
+	if onlyS {
+		r.Add(a[i:]...)
+	}
+	if onlyOther {
+		r.Add(b[j:]...)
+	}
+	return r
+}
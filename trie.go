@@ -15,3 +15,282 @@
  */
 
 package gotypes
+
+import "sort"
+
+var (
+	_ Container                = (*Trie[int])(nil)
+	_ Enumerable2[string, int] = (*Trie[int])(nil)
+)
+
+type trieNode[V any] struct {
+	unit     rune
+	children []*trieNode[V]
+	value    V
+	hasValue bool
+}
+
+func (n *trieNode[V]) child(unit rune) *trieNode[V] {
+	i := n.search(unit)
+	if i < len(n.children) && n.children[i].unit == unit {
+		return n.children[i]
+	}
+	return nil
+}
+
+func (n *trieNode[V]) search(unit rune) int {
+	return sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].unit >= unit
+	})
+}
+
+func (n *trieNode[V]) addChild(unit rune) *trieNode[V] {
+	i := n.search(unit)
+	if i < len(n.children) && n.children[i].unit == unit {
+		return n.children[i]
+	}
+	c := &trieNode[V]{unit: unit}
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = c
+	return c
+}
+
+func (n *trieNode[V]) removeChild(unit rune) {
+	i := n.search(unit)
+	if i < len(n.children) && n.children[i].unit == unit {
+		copy(n.children[i:], n.children[i+1:])
+		n.children[len(n.children)-1] = nil
+		n.children = n.children[:len(n.children)-1]
+	}
+}
+
+// Trie is a prefix tree keyed by strings.
+// A trie created by NewTrie splits keys into runes, one created by NewByteTrie splits keys into bytes.
+// Traversal visits keys in lexicographic order.
+type Trie[V any] struct {
+	root     *trieNode[V]
+	size     int
+	byteWise bool
+}
+
+// NewTrie return a Trie that splits keys into runes,
+// invalid UTF-8 sequences in keys are treated as utf8.RuneError.
+func NewTrie[V any]() *Trie[V] {
+	return &Trie[V]{root: &trieNode[V]{}}
+}
+
+// NewByteTrie return a Trie that splits keys into bytes
+func NewByteTrie[V any]() *Trie[V] {
+	return &Trie[V]{root: &trieNode[V]{}, byteWise: true}
+}
+
+// Insert stores the value for the key, replacing any existing value.
+func (t *Trie[V]) Insert(key string, value V) {
+	if t.root == nil {
+		t.root = &trieNode[V]{}
+	}
+	node := t.root
+	for _, u := range t.units(key) {
+		node = node.addChild(u)
+	}
+	if !node.hasValue {
+		t.size++
+	}
+	node.value = value
+	node.hasValue = true
+}
+
+// Get returns the value stored for the key and whether it was found.
+func (t *Trie[V]) Get(key string) (V, bool) {
+	node := t.find(key)
+	if node == nil || !node.hasValue {
+		return *new(V), false
+	}
+	return node.value, true
+}
+
+// Delete removes the key from the trie and reports whether it was present.
+func (t *Trie[V]) Delete(key string) bool {
+	if t.root == nil {
+		return false
+	}
+	units := t.units(key)
+	path := make([]*trieNode[V], 0, len(units)+1)
+	node := t.root
+	path = append(path, node)
+	for _, u := range units {
+		if node = node.child(u); node == nil {
+			return false
+		}
+		path = append(path, node)
+	}
+	if !node.hasValue {
+		return false
+	}
+	node.value = *new(V)
+	node.hasValue = false
+	t.size--
+
+	for i := len(path) - 1; i > 0; i-- {
+		n := path[i]
+		if n.hasValue || len(n.children) > 0 {
+			break
+		}
+		path[i-1].removeChild(n.unit)
+	}
+	return true
+}
+
+// HasPrefix reports whether any key in the trie begins with prefix.
+func (t *Trie[V]) HasPrefix(prefix string) bool {
+	node := t.find(prefix)
+	return node != nil && (node.hasValue || len(node.children) > 0)
+}
+
+// LongestPrefixMatch returns the longest key in the trie that is a prefix of s.
+func (t *Trie[V]) LongestPrefixMatch(s string) (key string, value V, ok bool) {
+	if t.root == nil {
+		return "", value, false
+	}
+	node := t.root
+	if node.hasValue {
+		value, ok = node.value, true
+	}
+	units := t.units(s)
+	matched := 0
+	for i, u := range units {
+		if node = node.child(u); node == nil {
+			break
+		}
+		if node.hasValue {
+			matched, value, ok = i+1, node.value, true
+		}
+	}
+	if !ok {
+		return "", value, false
+	}
+	return t.join(units[:matched]), value, true
+}
+
+// WalkPrefix calls f in lexicographic order for each key beginning with prefix,
+// the walk stops if f returns false.
+func (t *Trie[V]) WalkPrefix(prefix string, f func(key string, value V) bool) {
+	node := t.find(prefix)
+	if node == nil {
+		return
+	}
+	units := t.units(prefix)
+	t.walk(node, units, f)
+}
+
+func (t *Trie[V]) Range(f func(key string, value V) bool) {
+	if t.root == nil {
+		return
+	}
+	t.walk(t.root, nil, f)
+}
+
+func (t *Trie[V]) Each(f func(key string, value V)) {
+	t.Range(func(k string, v V) bool {
+		f(k, v)
+		return true
+	})
+}
+
+func (t *Trie[V]) Every(f func(key string, value V) bool) bool {
+	ok := true
+	t.Range(func(k string, v V) bool {
+		ok = f(k, v)
+		return ok
+	})
+	return ok
+}
+
+func (t *Trie[V]) Some(f func(key string, value V) bool) bool {
+	ok := false
+	t.Range(func(k string, v V) bool {
+		ok = f(k, v)
+		return !ok
+	})
+	return ok
+}
+
+// Keys returns all keys in lexicographic order
+func (t *Trie[V]) Keys() []string {
+	keys := make([]string, 0, t.size)
+	t.Each(func(k string, _ V) {
+		keys = append(keys, k)
+	})
+	return keys
+}
+
+// Values returns all values in the lexicographic order of their keys
+func (t *Trie[V]) Values() []V {
+	values := make([]V, 0, t.size)
+	t.Each(func(_ string, v V) {
+		values = append(values, v)
+	})
+	return values
+}
+
+func (t *Trie[V]) Empty() bool {
+	return t.size == 0
+}
+
+func (t *Trie[V]) Size() int {
+	return t.size
+}
+
+func (t *Trie[V]) RemoveAll() {
+	t.root = &trieNode[V]{}
+	t.size = 0
+}
+
+func (t *Trie[V]) find(key string) *trieNode[V] {
+	node := t.root
+	if node == nil {
+		return nil
+	}
+	for _, u := range t.units(key) {
+		if node = node.child(u); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// walk visits node and its descendants in preorder, it returns false if f stopped the walk.
+func (t *Trie[V]) walk(node *trieNode[V], path []rune, f func(key string, value V) bool) bool {
+	if node.hasValue && !f(t.join(path), node.value) {
+		return false
+	}
+	for _, c := range node.children {
+		if !t.walk(c, append(path, c.unit), f) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *Trie[V]) units(key string) []rune {
+	if !t.byteWise {
+		return []rune(key)
+	}
+	units := make([]rune, len(key))
+	for i := 0; i < len(key); i++ {
+		units[i] = rune(key[i])
+	}
+	return units
+}
+
+func (t *Trie[V]) join(units []rune) string {
+	if !t.byteWise {
+		return string(units)
+	}
+	b := make([]byte, len(units))
+	for i, u := range units {
+		b[i] = byte(u)
+	}
+	return string(b)
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2022-2023 Lynn <lynnplus90@gmail.com>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gotypes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrie(t *testing.T) {
+	for _, trie := range []*Trie[int]{NewTrie[int](), NewByteTrie[int]()} {
+		for i, k := range []string{"team", "tea", "ten", "to", "世界", "世"} {
+			trie.Insert(k, i)
+		}
+		if trie.Size() != 6 {
+			t.Fatalf("size = %d, want 6", trie.Size())
+		}
+		if v, ok := trie.Get("tea"); !ok || v != 1 {
+			t.Errorf("Get(tea) = %v, %v", v, ok)
+		}
+		if _, ok := trie.Get("te"); ok {
+			t.Errorf("Get(te) found a value")
+		}
+		if !trie.HasPrefix("te") || trie.HasPrefix("tx") {
+			t.Errorf("HasPrefix mismatch")
+		}
+		if k, v, ok := trie.LongestPrefixMatch("teamwork"); !ok || k != "team" || v != 0 {
+			t.Errorf("LongestPrefixMatch(teamwork) = %q, %v, %v", k, v, ok)
+		}
+		if k, _, ok := trie.LongestPrefixMatch("世界和平"); !ok || k != "世界" {
+			t.Errorf("LongestPrefixMatch(世界和平) = %q, %v", k, ok)
+		}
+
+		var keys []string
+		trie.WalkPrefix("te", func(key string, _ int) bool {
+			keys = append(keys, key)
+			return true
+		})
+		if want := []string{"tea", "team", "ten"}; !reflect.DeepEqual(keys, want) {
+			t.Errorf("WalkPrefix(te) = %v, want %v", keys, want)
+		}
+
+		if !trie.Delete("tea") || trie.Delete("tea") {
+			t.Errorf("Delete(tea) mismatch")
+		}
+		if want := []string{"team", "ten", "to", "世", "世界"}; !reflect.DeepEqual(trie.Keys(), want) {
+			t.Errorf("Keys() = %v, want %v", trie.Keys(), want)
+		}
+		trie.RemoveAll()
+		if !trie.Empty() || trie.HasPrefix("t") {
+			t.Errorf("trie is not empty after RemoveAll")
+		}
+	}
+}